@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"net/http"
+
+	"movie-booking/api/v1/helpers"
+	"movie-booking/api/v1/types"
+	"movie-booking/constants"
+	appcontext "movie-booking/util/context"
+	"movie-booking/util/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// CheckInBookingHandler handles POST /api/v1/admin/bookings/:id/check-in
+func (c *Controller) CheckInBookingHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	return c.changeBookingStatus(r, "[CheckInBooking]", constants.BookingStatusCheckedIn)
+}
+
+// MarkBookingRefundedHandler handles POST /api/v1/admin/bookings/:id/refund
+func (c *Controller) MarkBookingRefundedHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	return c.changeBookingStatus(r, "[MarkBookingRefunded]", constants.BookingStatusRefunded)
+}
+
+// changeBookingStatus moves the booking in the path to status on behalf of staff
+func (c *Controller) changeBookingStatus(r *http.Request, TAG string, status constants.BookingStatus) (*types.GenericAPIResponse, error) {
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if !appcontext.IsStaff(ctx) {
+		return nil, errors.NewHTTPError(http.StatusForbidden, "staff role required")
+	}
+
+	// Parse booking ID from path
+	bookingID, err := helpers.ParseBookingIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid booking ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"bookingID": bookingID,
+		"status":    status,
+		"userID":    userID,
+	}).Info(TAG, "Change booking status request")
+
+	// Call service layer
+	var result *types.BookingStatusResponse
+	if status == constants.BookingStatusCheckedIn {
+		result, err = c.bookingService.CheckInBooking(ctx, bookingID, userID)
+	} else {
+		result, err = c.bookingService.MarkBookingRefunded(ctx, bookingID, userID)
+	}
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to change booking status")
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"from": result.FromStatus,
+		"to":   result.Status,
+	}).Info(TAG, "Booking status changed")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
+// ConfirmOrderPaymentHandler handles POST /api/v1/admin/orders/:id/payment
+func (c *Controller) ConfirmOrderPaymentHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[ConfirmOrderPayment]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if !appcontext.IsStaff(ctx) {
+		return nil, errors.NewHTTPError(http.StatusForbidden, "staff role required")
+	}
+
+	// Parse order ID from path
+	orderID, err := helpers.ParseOrderIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid order ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"orderID": orderID,
+		"userID":  userID,
+	}).Info(TAG, "Confirm order payment request")
+
+	// Call service layer
+	result, err := c.bookingService.ConfirmOrderPayment(ctx, orderID, userID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to confirm order payment")
+		return nil, err
+	}
+
+	logger.WithField("orderID", result.OrderID).Info(TAG, "Order payment confirmed")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
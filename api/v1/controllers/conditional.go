@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"movie-booking/api/v1/types"
+)
+
+// notModifiedResponse tells ResponseHandler to answer 304 with no body
+func notModifiedResponse() *types.GenericAPIResponse {
+	return &types.GenericAPIResponse{Success: true, StatusCode: http.StatusNotModified}
+}
+
+// checkETag sets the response ETag and reports whether the request's If-None-Match already names it
+func checkETag(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLastModified sets Last-Modified and reports whether the request's If-Modified-Since is no older than it.
+// A zero lastModified (nothing to report) never matches.
+func checkLastModified(w http.ResponseWriter, r *http.Request, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if r.Header.Get("If-None-Match") != "" {
+		return false // If-None-Match takes precedence and this resource has no ETag
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.After(since)
+}
+
+// seatMapETag names a version of a show's seats; the flat list and the map are different representations
+func seatMapETag(showID uint, version uint64, flat bool) string {
+	if flat {
+		return fmt.Sprintf(`"seats-%d-%d-flat"`, showID, version)
+	}
+	return fmt.Sprintf(`"seats-%d-%d"`, showID, version)
+}
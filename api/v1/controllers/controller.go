@@ -3,9 +3,11 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"movie-booking/api/v1/helpers"
 	"movie-booking/api/v1/types"
+	"movie-booking/config"
 	"movie-booking/core/services"
 	appcontext "movie-booking/util/context"
 	"movie-booking/util/errors"
@@ -19,6 +21,9 @@ type Controller struct {
 	showService    services.ShowServiceInterface
 	seatService    services.SeatServiceInterface
 	bookingService services.BookingServiceInterface
+	waitingRoom    services.WaitingRoomServiceInterface
+	waitlist       services.WaitlistServiceInterface
+	seatSockets    *seatSocketRegistry
 }
 
 // NewController creates a new controller instance
@@ -28,6 +33,8 @@ func NewController(
 	showService services.ShowServiceInterface,
 	seatService services.SeatServiceInterface,
 	bookingService services.BookingServiceInterface,
+	waitingRoom services.WaitingRoomServiceInterface,
+	waitlist services.WaitlistServiceInterface,
 ) *Controller {
 	return &Controller{
 		authService:    authService,
@@ -35,6 +42,9 @@ func NewController(
 		showService:    showService,
 		seatService:    seatService,
 		bookingService: bookingService,
+		waitingRoom:    waitingRoom,
+		waitlist:       waitlist,
+		seatSockets:    newSeatSocketRegistry(),
 	}
 }
 
@@ -62,6 +72,7 @@ func ErrorHandler(err error, w http.ResponseWriter, r *http.Request) {
 	if httpErr, ok := errors.IsHTTPError(err); ok {
 		response.StatusCode = httpErr.StatusCode
 		response.Message = httpErr.Message
+		response.ErrorCode = httpErr.Code
 	} else {
 		// Check for common error types
 		switch {
@@ -84,7 +95,13 @@ func writeGenericResponse(res *types.GenericAPIResponse, w http.ResponseWriter,
 	// Ensure CORS headers are set (backup in case middleware didn't set them)
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key, X-Admission-Token, If-None-Match, If-Modified-Since")
+
+	// A conditional GET that matched carries no body
+	if res.StatusCode == http.StatusNotModified {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 	
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(res.StatusCode)
@@ -136,6 +153,17 @@ func (c *Controller) GetMoviesHandler(w http.ResponseWriter, r *http.Request) (*
 		return nil, errors.Wrap(err, "failed to get movies")
 	}
 
+	// Let pollers skip the payload when no movie changed
+	var lastModified time.Time
+	for _, movie := range movies {
+		if movie.UpdatedAt.After(lastModified) {
+			lastModified = movie.UpdatedAt
+		}
+	}
+	if checkLastModified(w, r, lastModified) {
+		return notModifiedResponse(), nil
+	}
+
 	return &types.GenericAPIResponse{
 		Success:    true,
 		StatusCode: http.StatusOK,
@@ -144,7 +172,7 @@ func (c *Controller) GetMoviesHandler(w http.ResponseWriter, r *http.Request) (*
 	}, nil
 }
 
-// GetShowsByMovieHandler handles GET /api/v1/movies/:id/shows
+// GetShowsByMovieHandler handles GET /api/v1/movies/:id/shows?format=&language=&subtitles=&date=
 func (c *Controller) GetShowsByMovieHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
 	TAG := "[GetShowsByMovie]"
 	ctx := r.Context()
@@ -156,14 +184,33 @@ func (c *Controller) GetShowsByMovieHandler(w http.ResponseWriter, r *http.Reque
 		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid movie ID")
 	}
 
-	logger.WithField("movieID", movieID).Info(TAG, "Get shows for movie")
+	filter, err := helpers.ParseShowFilterFromQuery(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"movieID": movieID,
+		"format":  filter.Format,
+	}).Info(TAG, "Get shows for movie")
 
-	shows, err := c.showService.GetShowsByMovieID(ctx, movieID)
+	shows, err := c.showService.GetShowsByMovieID(ctx, movieID, filter)
 	if err != nil {
 		logger.WithError(err).Error(TAG, "Failed to get shows")
 		return nil, errors.Wrap(err, "failed to get shows")
 	}
 
+	// Let pollers skip the payload when no listed show changed
+	var lastModified time.Time
+	for _, show := range shows {
+		if show.UpdatedAt.After(lastModified) {
+			lastModified = show.UpdatedAt
+		}
+	}
+	if checkLastModified(w, r, lastModified) {
+		return notModifiedResponse(), nil
+	}
+
 	return &types.GenericAPIResponse{
 		Success:    true,
 		StatusCode: http.StatusOK,
@@ -184,9 +231,36 @@ func (c *Controller) GetSeatsByShowHandler(w http.ResponseWriter, r *http.Reques
 		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
 	}
 
-	logger.WithField("showID", showID).Info(TAG, "Get seats for show")
+	flat, err := helpers.ParseFlatSeatsFlag(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{"showID": showID, "flat": flat}).Info(TAG, "Get seats for show")
+
+	// Polling clients send back the ETag; skip building the map if no seat changed since
+	version, err := c.seatService.GetSeatMapVersion(ctx, showID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to get seat version")
+		return nil, errors.Wrap(err, "failed to get seats")
+	}
+	if checkETag(w, r, seatMapETag(showID, version, flat)) {
+		return notModifiedResponse(), nil
+	}
 
-	seats, err := c.seatService.GetSeatsByShowID(ctx, showID)
+	// Old clients ask for the flat list with ?flat=true
+	var values interface{}
+	if flat {
+		values, err = c.seatService.GetSeatsByShowID(ctx, showID)
+	} else {
+		var seatMap *types.SeatMapResponse
+		seatMap, err = c.seatService.GetSeatMap(ctx, showID)
+		if err == nil {
+			// The map may be newer than the version checked above
+			w.Header().Set("ETag", seatMapETag(showID, seatMap.Version, flat))
+			values = seatMap
+		}
+	}
 	if err != nil {
 		logger.WithError(err).Error(TAG, "Failed to get seats")
 		return nil, errors.Wrap(err, "failed to get seats")
@@ -196,10 +270,90 @@ func (c *Controller) GetSeatsByShowHandler(w http.ResponseWriter, r *http.Reques
 		Success:    true,
 		StatusCode: http.StatusOK,
 		Message:    "Seats retrieved successfully",
-		Values:     seats,
+		Values:     values,
 	}, nil
 }
 
+// StreamSeatsHandler handles GET /api/v1/shows/:id/seats/stream (Server-Sent Events)
+func (c *Controller) StreamSeatsHandler(w http.ResponseWriter, r *http.Request) {
+	TAG := "[StreamSeats]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		ErrorHandler(errors.NewHTTPError(http.StatusBadRequest, "invalid show ID"), w, r)
+		return
+	}
+
+	lastEventID, err := helpers.ParseLastEventID(r)
+	if err != nil {
+		ErrorHandler(errors.NewHTTPError(http.StatusBadRequest, err.Error()), w, r)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		ErrorHandler(errors.NewHTTPError(http.StatusInternalServerError, "streaming not supported"), w, r)
+		return
+	}
+
+	// Call service layer
+	sub, replay, err := c.seatService.SubscribeSeatEvents(ctx, showID, lastEventID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to subscribe to seat events")
+		ErrorHandler(err, w, r)
+		return
+	}
+	defer sub.Close()
+
+	logger.WithFields(logrus.Fields{
+		"showID":      showID,
+		"lastEventID": lastEventID,
+		"replayed":    len(replay),
+	}).Info(TAG, "Seat stream opened")
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range replay {
+		if err := helpers.WriteSSEEvent(w, event.ID, event.Type, event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(config.GetSeatStreamHeartbeatInterval())
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.WithField("showID", showID).Info(TAG, "Seat stream closed by client")
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				// The hub dropped this subscriber for falling behind; the client reconnects with Last-Event-ID
+				logger.WithField("showID", showID).Warn(TAG, "Seat stream subscriber fell behind")
+				return
+			}
+			if err := helpers.WriteSSEEvent(w, event.ID, event.Type, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if err := helpers.WriteSSEComment(w, "heartbeat"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
 // LockSeatHandler handles PATCH /api/v1/seats/:id/lock
 func (c *Controller) LockSeatHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
 	TAG := "[LockSeat]"
@@ -223,6 +377,11 @@ func (c *Controller) LockSeatHandler(w http.ResponseWriter, r *http.Request) (*t
 		"userID": userID,
 	}).Info(TAG, "Lock seat request")
 
+	// Shows with a waiting room only accept admitted users
+	if err := c.checkSeatAdmission(r, seatID, userID); err != nil {
+		return nil, err
+	}
+
 	// Call service layer
 	result, err := c.seatService.LockSeat(ctx, seatID, userID)
 	if err != nil {
@@ -240,6 +399,272 @@ func (c *Controller) LockSeatHandler(w http.ResponseWriter, r *http.Request) (*t
 	}, nil
 }
 
+// ReleaseSeatHandler handles DELETE /api/v1/seats/:id/lock
+func (c *Controller) ReleaseSeatHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[ReleaseSeat]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse seat ID from path
+	seatID, err := helpers.ParseSeatIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid seat ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"seatID": seatID,
+		"userID": userID,
+	}).Info(TAG, "Release seat request")
+
+	// Call service layer
+	result, err := c.seatService.ReleaseSeat(ctx, seatID, userID, appcontext.IsStaff(ctx))
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to release seat")
+		return nil, err
+	}
+
+	logger.Info(TAG, "Seat released successfully")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
+// ReleaseHoldHandler handles DELETE /api/v1/holds/:id
+func (c *Controller) ReleaseHoldHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[ReleaseHold]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse hold ID from path
+	holdID, err := helpers.ParseHoldIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid hold ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"holdID": holdID,
+		"userID": userID,
+	}).Info(TAG, "Release hold request")
+
+	// Call service layer
+	result, err := c.seatService.ReleaseHold(ctx, holdID, userID, appcontext.IsStaff(ctx))
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to release hold")
+		return nil, err
+	}
+
+	logger.WithField("seatIDs", result.SeatIDs).Info(TAG, "Hold released successfully")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
+// RenewHoldHandler handles POST /api/v1/holds/:id/renew
+func (c *Controller) RenewHoldHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[RenewHold]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse hold ID from path
+	holdID, err := helpers.ParseHoldIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid hold ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"holdID": holdID,
+		"userID": userID,
+	}).Info(TAG, "Renew hold request")
+
+	// Call service layer
+	result, err := c.seatService.RenewHold(ctx, holdID, userID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to renew hold")
+		return nil, err
+	}
+
+	logger.WithField("expiresAt", result.ExpiresAt).Info(TAG, "Hold renewed successfully")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
+// GetSeatTimelineHandler handles GET /api/v1/admin/seats/:id/events
+func (c *Controller) GetSeatTimelineHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[GetSeatTimeline]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if !appcontext.IsStaff(ctx) {
+		return nil, errors.NewHTTPError(http.StatusForbidden, "staff role required")
+	}
+
+	// Parse seat ID from path
+	seatID, err := helpers.ParseSeatIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid seat ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"seatID": seatID,
+		"userID": userID,
+	}).Info(TAG, "Get seat timeline request")
+
+	// Call service layer
+	result, err := c.seatService.GetSeatTimeline(ctx, seatID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to get seat timeline")
+		return nil, err
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Seat timeline retrieved successfully",
+		Values:     result,
+	}, nil
+}
+
+// LockSeatsHandler handles POST /api/v1/shows/:id/locks
+func (c *Controller) LockSeatsHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[LockSeats]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	// Parse and validate request
+	req, err := helpers.ValidateAndParseLockSeatsRequest(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID":  showID,
+		"seatIDs": req.SeatIDs,
+		"userID":  userID,
+	}).Info(TAG, "Lock seats request")
+
+	// Shows with a waiting room only accept admitted users
+	if err := c.checkAdmission(r, showID, userID); err != nil {
+		return nil, err
+	}
+
+	// Call service layer
+	result, err := c.seatService.LockSeats(ctx, showID, req.SeatIDs, userID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to lock seats")
+		return nil, err
+	}
+
+	logger.WithField("holdID", result.HoldID).Info(TAG, "Seats locked successfully")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
+// BestAvailableHandler handles POST /api/v1/shows/:id/best-available
+func (c *Controller) BestAvailableHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[BestAvailable]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	// Parse and validate request
+	req, err := helpers.ValidateAndParseBestAvailableRequest(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID":   showID,
+		"quantity": req.Quantity,
+		"category": req.Category,
+		"userID":   userID,
+	}).Info(TAG, "Best available request")
+
+	// Shows with a waiting room only accept admitted users
+	if err := c.checkAdmission(r, showID, userID); err != nil {
+		return nil, err
+	}
+
+	// Call service layer
+	result, err := c.seatService.LockBestAvailable(ctx, showID, req.Quantity, req.Category, userID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to select best available seats")
+		return nil, err
+	}
+
+	logger.WithField("seatNames", result.SeatNames).Info(TAG, "Best available seats locked")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
 // CreateBookingHandler handles POST /api/v1/bookings
 func (c *Controller) CreateBookingHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
 	TAG := "[CreateBooking]"
@@ -259,11 +684,17 @@ func (c *Controller) CreateBookingHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	logger.WithFields(logrus.Fields{
-		"showID": input.ShowID,
-		"seatID": input.SeatID,
-		"userID": userID,
+		"showID":  input.ShowID,
+		"holdID":  input.HoldID,
+		"seatIDs": input.SeatIDs,
+		"userID":  userID,
 	}).Info(TAG, "Create booking request")
 
+	// Shows with a waiting room only accept admitted users
+	if err := c.checkAdmission(r, input.ShowID, userID); err != nil {
+		return nil, err
+	}
+
 	// Call service layer
 	result, err := c.bookingService.CreateBooking(ctx, input)
 	if err != nil {
@@ -271,7 +702,124 @@ func (c *Controller) CreateBookingHandler(w http.ResponseWriter, r *http.Request
 		return nil, err
 	}
 
-	logger.Info(TAG, "Booking created successfully")
+	logger.WithField("orderID", result.OrderID).Info(TAG, "Booking created successfully")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
+// ListBookingsHandler handles GET /api/v1/bookings
+func (c *Controller) ListBookingsHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[ListBookings]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse and validate query
+	req, err := helpers.ParseListBookingsRequest(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"userID": userID,
+		"period": req.Period,
+		"limit":  req.Limit,
+	}).Info(TAG, "List bookings request")
+
+	// Call service layer
+	result, err := c.bookingService.ListBookings(ctx, userID, req)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to list bookings")
+		return nil, err
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Bookings retrieved successfully",
+		Values:     result,
+	}, nil
+}
+
+// GetBookingHandler handles GET /api/v1/bookings/:id
+func (c *Controller) GetBookingHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[GetBooking]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse booking ID from path
+	bookingID, err := helpers.ParseBookingIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid booking ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"bookingID": bookingID,
+		"userID":    userID,
+	}).Info(TAG, "Get booking request")
+
+	// Call service layer
+	result, err := c.bookingService.GetBooking(ctx, bookingID, userID, appcontext.IsStaff(ctx))
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to get booking")
+		return nil, err
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Booking retrieved successfully",
+		Values:     result,
+	}, nil
+}
+
+// CancelBookingHandler handles POST /api/v1/bookings/:id/cancel
+func (c *Controller) CancelBookingHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[CancelBooking]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse booking ID from path
+	bookingID, err := helpers.ParseBookingIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid booking ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"bookingID": bookingID,
+		"userID":    userID,
+	}).Info(TAG, "Cancel booking request")
+
+	// Call service layer
+	result, err := c.bookingService.CancelBooking(ctx, bookingID, userID, appcontext.IsStaff(ctx))
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to cancel booking")
+		return nil, err
+	}
+
+	logger.WithField("refundAmount", result.RefundAmount).Info(TAG, "Booking cancelled successfully")
 
 	return &types.GenericAPIResponse{
 		Success:    true,
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"net/http"
+
+	"movie-booking/api/v1/helpers"
+	"movie-booking/api/v1/types"
+	appcontext "movie-booking/util/context"
+	"movie-booking/util/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// BlockShowSeatsHandler handles POST /api/v1/admin/shows/:id/seats/block
+func (c *Controller) BlockShowSeatsHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	return c.changeShowSeatBlocks(r, "[BlockShowSeats]", true)
+}
+
+// UnblockShowSeatsHandler handles POST /api/v1/admin/shows/:id/seats/unblock
+func (c *Controller) UnblockShowSeatsHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	return c.changeShowSeatBlocks(r, "[UnblockShowSeats]", false)
+}
+
+// BlockScreenSeatsHandler handles POST /api/v1/admin/screens/:id/seats/block
+func (c *Controller) BlockScreenSeatsHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	return c.changeScreenSeatBlocks(r, "[BlockScreenSeats]", true)
+}
+
+// UnblockScreenSeatsHandler handles POST /api/v1/admin/screens/:id/seats/unblock
+func (c *Controller) UnblockScreenSeatsHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	return c.changeScreenSeatBlocks(r, "[UnblockScreenSeats]", false)
+}
+
+// changeShowSeatBlocks blocks or unblocks seats of the show in the path
+func (c *Controller) changeShowSeatBlocks(r *http.Request, TAG string, block bool) (*types.GenericAPIResponse, error) {
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if !appcontext.IsAdmin(ctx) {
+		return nil, errors.NewHTTPError(http.StatusForbidden, "admin role required")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	// Parse and validate request
+	req, err := helpers.ValidateAndParseBlockSeatsRequest(r, block)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID":  showID,
+		"seatIDs": req.SeatIDs,
+		"block":   block,
+		"reason":  req.Reason,
+		"userID":  userID,
+	}).Info(TAG, "Change seat blocks request")
+
+	// Call service layer
+	var result *types.SeatBlockResponse
+	if block {
+		result, err = c.seatService.BlockSeats(ctx, showID, req.SeatIDs, req.Reason, userID)
+	} else {
+		result, err = c.seatService.UnblockSeats(ctx, showID, req.SeatIDs, req.Reason, userID)
+	}
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to change seat blocks")
+		return nil, err
+	}
+
+	logger.WithField("seatIDs", result.SeatIDs).Info(TAG, "Seat blocks changed")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
+
+// changeScreenSeatBlocks blocks or unblocks seats by name on every upcoming show of the screen in the path
+func (c *Controller) changeScreenSeatBlocks(r *http.Request, TAG string, block bool) (*types.GenericAPIResponse, error) {
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if !appcontext.IsAdmin(ctx) {
+		return nil, errors.NewHTTPError(http.StatusForbidden, "admin role required")
+	}
+
+	// Parse screen ID from path
+	screenID, err := helpers.ParseScreenIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid screen ID")
+	}
+
+	// Parse and validate request
+	req, err := helpers.ValidateAndParseBlockScreenSeatsRequest(r, block)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"screenID":  screenID,
+		"seatNames": req.SeatNames,
+		"block":     block,
+		"reason":    req.Reason,
+		"userID":    userID,
+	}).Info(TAG, "Change screen seat blocks request")
+
+	// Call service layer
+	var result *types.SeatBlockResponse
+	if block {
+		result, err = c.seatService.BlockScreenSeats(ctx, screenID, req.SeatNames, req.Reason, userID)
+	} else {
+		result, err = c.seatService.UnblockScreenSeats(ctx, screenID, req.SeatNames, req.Reason, userID)
+	}
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to change screen seat blocks")
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showIDs": result.ShowIDs,
+		"changed": len(result.SeatIDs),
+		"skipped": len(result.Skipped),
+	}).Info(TAG, "Screen seat blocks changed")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    result.Message,
+		Values:     result,
+	}, nil
+}
@@ -0,0 +1,370 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"movie-booking/api/v1/helpers"
+	"movie-booking/api/v1/types"
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/events"
+	appcontext "movie-booking/util/context"
+	"movie-booking/util/errors"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+// seatSocketMaxMessageSize bounds a single client command
+const seatSocketMaxMessageSize = 4096
+
+// seatSocketUpgrader accepts any origin, matching the API's open CORS policy
+var seatSocketUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// seatSocketKey identifies a user's session on one show
+type seatSocketKey struct {
+	userID uint
+	showID uint
+}
+
+// pendingHoldRelease is a dropped session's holds waiting out the grace period
+type pendingHoldRelease struct {
+	timer   *time.Timer
+	holdIDs []uint
+}
+
+// seatSocketRegistry keeps the holds of dropped connections so a quick reconnect can adopt them
+type seatSocketRegistry struct {
+	mu      sync.Mutex
+	pending map[seatSocketKey]*pendingHoldRelease
+}
+
+func newSeatSocketRegistry() *seatSocketRegistry {
+	return &seatSocketRegistry{pending: make(map[seatSocketKey]*pendingHoldRelease)}
+}
+
+// adopt cancels a pending release for the session and hands its holds to the new connection
+func (reg *seatSocketRegistry) adopt(key seatSocketKey) []uint {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	pending, ok := reg.pending[key]
+	if !ok {
+		return nil
+	}
+	delete(reg.pending, key)
+	if !pending.timer.Stop() {
+		// The release already started
+		return nil
+	}
+	return pending.holdIDs
+}
+
+// scheduleRelease runs release for the holds once the grace period passes without a reconnect.
+// A release still pending for the session is cancelled and its holds join this one, so a reconnect adopts them all.
+func (reg *seatSocketRegistry) scheduleRelease(key seatSocketKey, holdIDs []uint, grace time.Duration, release func([]uint)) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	pending := &pendingHoldRelease{holdIDs: holdIDs}
+	if previous, ok := reg.pending[key]; ok && previous.timer.Stop() {
+		pending.holdIDs = mergeHoldIDs(previous.holdIDs, holdIDs)
+	}
+	pending.timer = time.AfterFunc(grace, func() {
+		reg.mu.Lock()
+		if reg.pending[key] == pending {
+			delete(reg.pending, key)
+		}
+		reg.mu.Unlock()
+		release(pending.holdIDs)
+	})
+	reg.pending[key] = pending
+}
+
+// mergeHoldIDs returns the hold IDs of both lists without duplicates
+func mergeHoldIDs(a, b []uint) []uint {
+	seen := make(map[uint]bool, len(a)+len(b))
+	merged := make([]uint, 0, len(a)+len(b))
+	for _, id := range append(append([]uint(nil), a...), b...) {
+		if !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+// seatSocketConn is one kiosk connection subscribed to a show
+type seatSocketConn struct {
+	ctrl    *Controller
+	conn    *websocket.Conn
+	logger  *logrus.Entry
+	userID  uint
+	showID  uint
+	isStaff bool
+
+	admissionToken string // Waiting room admission, checked again on every lock
+
+	send      chan types.SeatSocketMessage
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	holdIDs map[uint]bool
+}
+
+// SeatSocketHandler handles GET /api/v1/shows/:id/ws (WebSocket)
+func (c *Controller) SeatSocketHandler(w http.ResponseWriter, r *http.Request) {
+	TAG := "[SeatSocket]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		ErrorHandler(errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated"), w, r)
+		return
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		ErrorHandler(errors.NewHTTPError(http.StatusBadRequest, "invalid show ID"), w, r)
+		return
+	}
+
+	// Shows with a waiting room only accept admitted users
+	if err := c.checkAdmission(r, showID, userID); err != nil {
+		ErrorHandler(err, w, r)
+		return
+	}
+
+	// Subscribe before upgrading so errors can still be returned as JSON
+	sub, _, err := c.seatService.SubscribeSeatEvents(ctx, showID, 0)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to subscribe to seat events")
+		ErrorHandler(err, w, r)
+		return
+	}
+	defer sub.Close()
+
+	conn, err := seatSocketUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already written an HTTP error
+		logger.WithError(err).Error(TAG, "WebSocket upgrade failed")
+		return
+	}
+
+	sc := &seatSocketConn{
+		ctrl:    c,
+		conn:    conn,
+		logger:  logger.WithFields(logrus.Fields{"showID": showID, "userID": userID}),
+		userID:  userID,
+		showID:  showID,
+		isStaff: appcontext.IsStaff(ctx),
+		send:    make(chan types.SeatSocketMessage, config.GetSeatSocketSendBufferSize()),
+		done:    make(chan struct{}),
+		holdIDs: make(map[uint]bool),
+
+		admissionToken: helpers.ParseAdmissionToken(r),
+	}
+
+	key := seatSocketKey{userID: userID, showID: showID}
+	for _, holdID := range c.seatSockets.adopt(key) {
+		sc.holdIDs[holdID] = true
+	}
+
+	sc.logger.WithField("adoptedHolds", len(sc.holdIDs)).Info(TAG, "Seat socket opened")
+
+	go sc.writeLoop()
+	go sc.forwardEvents(sub)
+	sc.readLoop(ctx)
+	sc.close(websocket.CloseNormalClosure, "")
+
+	// Give the client a chance to reconnect before its holds are released
+	if holdIDs := sc.heldIDs(); len(holdIDs) > 0 {
+		c.seatSockets.scheduleRelease(key, holdIDs, config.GetSeatSocketHoldReleaseGrace(), func(holdIDs []uint) {
+			for _, holdID := range holdIDs {
+				if _, err := c.seatService.ReleaseHold(context.Background(), holdID, userID, false); err != nil {
+					sc.logger.WithError(err).WithField("holdID", holdID).Info(TAG, "Hold not released after disconnect")
+				}
+			}
+		})
+	}
+
+	sc.logger.Info(TAG, "Seat socket closed")
+}
+
+// readLoop processes client commands until the connection fails or stops answering pings
+func (sc *seatSocketConn) readLoop(ctx context.Context) {
+	pongTimeout := config.GetSeatSocketPongTimeout()
+	sc.conn.SetReadLimit(seatSocketMaxMessageSize)
+	sc.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	sc.conn.SetPongHandler(func(string) error {
+		return sc.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+
+	for {
+		var cmd types.SeatSocketCommand
+		if err := sc.conn.ReadJSON(&cmd); err != nil {
+			return
+		}
+		sc.handleCommand(ctx, cmd)
+	}
+}
+
+// handleCommand runs a lock or release command through the seat service and replies to the sender
+func (sc *seatSocketConn) handleCommand(ctx context.Context, cmd types.SeatSocketCommand) {
+	var result interface{}
+	var err error
+
+	switch cmd.Type {
+	case constants.SocketCommandLock:
+		// Commands are checked like the HTTP lock request before reaching the service
+		seatIDs := append([]uint(nil), cmd.SeatIDs...)
+		if cmd.SeatID != 0 {
+			seatIDs = append(seatIDs, cmd.SeatID)
+		}
+		if len(seatIDs) == 0 {
+			err = errors.NewHTTPErrorWithCode(http.StatusBadRequest, constants.ErrCodeBadRequest, "seat_id or seat_ids is required")
+			break
+		}
+		if validErr := helpers.ValidateSeatIDs(seatIDs); validErr != nil {
+			err = errors.NewHTTPErrorWithCode(http.StatusBadRequest, constants.ErrCodeBadRequest, validErr.Error())
+			break
+		}
+		// The admission may end while the socket stays open
+		if !sc.isStaff {
+			if err = sc.ctrl.waitingRoom.CheckAdmission(ctx, sc.showID, sc.userID, sc.admissionToken); err != nil {
+				break
+			}
+		}
+		lock, lockErr := sc.ctrl.seatService.LockSeats(ctx, sc.showID, seatIDs, sc.userID)
+		if lockErr == nil {
+			sc.trackHold(lock.HoldID, true)
+		}
+		result, err = lock, lockErr
+	case constants.SocketCommandRelease:
+		switch {
+		case cmd.HoldID != 0:
+			release, releaseErr := sc.ctrl.seatService.ReleaseHold(ctx, cmd.HoldID, sc.userID, sc.isStaff)
+			if releaseErr == nil {
+				sc.trackHold(cmd.HoldID, false)
+			}
+			result, err = release, releaseErr
+		case cmd.SeatID != 0:
+			result, err = sc.ctrl.seatService.ReleaseSeat(ctx, cmd.SeatID, sc.userID, sc.isStaff)
+		default:
+			err = errors.NewHTTPErrorWithCode(http.StatusBadRequest, constants.ErrCodeBadRequest, "seat_id or hold_id is required")
+		}
+	default:
+		err = errors.NewHTTPErrorWithCode(http.StatusBadRequest, constants.ErrCodeBadRequest, "unknown command: "+cmd.Type)
+	}
+
+	if err != nil {
+		msg := types.SeatSocketMessage{
+			Type:      constants.SocketMessageError,
+			RequestID: cmd.RequestID,
+			Message:   "Internal server error",
+		}
+		if httpErr, ok := errors.IsHTTPError(err); ok {
+			msg.Message = httpErr.Message
+			msg.ErrorCode = httpErr.Code
+		} else {
+			sc.logger.WithError(err).Error("[SeatSocket]", "Command failed")
+		}
+		sc.enqueue(msg)
+		return
+	}
+
+	sc.enqueue(types.SeatSocketMessage{
+		Type:      constants.SocketMessageResult,
+		RequestID: cmd.RequestID,
+		Values:    result,
+	})
+}
+
+// forwardEvents relays hub events for the show until the subscription ends
+func (sc *seatSocketConn) forwardEvents(sub *events.Subscription) {
+	for event := range sub.Events {
+		event := event
+		sc.enqueue(types.SeatSocketMessage{Type: constants.SocketMessageSeat, Event: &event})
+	}
+	// The hub drops subscribers that fall behind
+	sc.close(websocket.CloseTryAgainLater, "seat updates fell behind")
+}
+
+// writeLoop is the connection's only data writer; it also sends heartbeat pings
+func (sc *seatSocketConn) writeLoop() {
+	ping := time.NewTicker(config.GetSeatSocketPingInterval())
+	defer ping.Stop()
+	writeTimeout := config.GetSeatSocketWriteTimeout()
+
+	for {
+		select {
+		case <-sc.done:
+			return
+		case msg := <-sc.send:
+			sc.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := sc.conn.WriteJSON(msg); err != nil {
+				sc.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ping.C:
+			sc.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := sc.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				sc.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
+
+// enqueue queues a message without blocking; a client whose buffer is full is disconnected
+func (sc *seatSocketConn) enqueue(msg types.SeatSocketMessage) {
+	select {
+	case <-sc.done:
+	case sc.send <- msg:
+	default:
+		sc.logger.Warn("[SeatSocket]", "Client too slow, closing connection")
+		sc.close(websocket.CloseTryAgainLater, "client too slow")
+	}
+}
+
+// close sends a close frame (best effort) and tears the connection down once
+func (sc *seatSocketConn) close(code int, reason string) {
+	sc.closeOnce.Do(func() {
+		close(sc.done)
+		if code != websocket.CloseAbnormalClosure {
+			deadline := time.Now().Add(config.GetSeatSocketWriteTimeout())
+			sc.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline)
+		}
+		sc.conn.Close()
+	})
+}
+
+func (sc *seatSocketConn) trackHold(holdID uint, held bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if held {
+		sc.holdIDs[holdID] = true
+	} else {
+		delete(sc.holdIDs, holdID)
+	}
+}
+
+func (sc *seatSocketConn) heldIDs() []uint {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	ids := make([]uint, 0, len(sc.holdIDs))
+	for id := range sc.holdIDs {
+		ids = append(ids, id)
+	}
+	return ids
+}
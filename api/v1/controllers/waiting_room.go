@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"net/http"
+
+	"movie-booking/api/v1/helpers"
+	"movie-booking/api/v1/types"
+	appcontext "movie-booking/util/context"
+	"movie-booking/util/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// SetWaitingRoomHandler handles PUT /api/v1/admin/shows/:id/waiting-room
+func (c *Controller) SetWaitingRoomHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[SetWaitingRoom]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+	if !appcontext.IsAdmin(ctx) {
+		return nil, errors.NewHTTPError(http.StatusForbidden, "admin role required")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	// Parse and validate request
+	req, err := helpers.ValidateAndParseWaitingRoomRequest(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID":  showID,
+		"enabled": *req.Enabled,
+		"userID":  userID,
+	}).Info(TAG, "Set waiting room request")
+
+	// Call service layer
+	result, err := c.waitingRoom.SetWaitingRoom(ctx, showID, *req.Enabled)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to set waiting room")
+		return nil, err
+	}
+
+	message := "Waiting room disabled"
+	if result.Enabled {
+		message = "Waiting room enabled"
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    message,
+		Values:     result,
+	}, nil
+}
+
+// JoinWaitingRoomHandler handles POST /api/v1/shows/:id/queue
+func (c *Controller) JoinWaitingRoomHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[JoinWaitingRoom]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID": showID,
+		"userID": userID,
+	}).Info(TAG, "Join waiting room request")
+
+	// Call service layer
+	result, err := c.waitingRoom.JoinWaitingRoom(ctx, showID, userID)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to join waiting room")
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"state":    result.State,
+		"position": result.Position,
+	}).Info(TAG, "Joined waiting room")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Joined waiting room",
+		Values:     result,
+	}, nil
+}
+
+// GetWaitingRoomStatusHandler handles GET /api/v1/shows/:id/queue
+func (c *Controller) GetWaitingRoomStatusHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[GetWaitingRoomStatus]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	// Call service layer
+	result, err := c.waitingRoom.GetWaitingRoomStatus(ctx, showID, userID)
+	if err != nil {
+		logger.WithError(err).Info(TAG, "Failed to get waiting room status")
+		return nil, err
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Waiting room status retrieved successfully",
+		Values:     result,
+	}, nil
+}
+
+// LeaveWaitingRoomHandler handles DELETE /api/v1/shows/:id/queue
+func (c *Controller) LeaveWaitingRoomHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[LeaveWaitingRoom]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID": showID,
+		"userID": userID,
+	}).Info(TAG, "Leave waiting room request")
+
+	// Call service layer
+	if err := c.waitingRoom.LeaveWaitingRoom(ctx, showID, userID); err != nil {
+		logger.WithError(err).Error(TAG, "Failed to leave waiting room")
+		return nil, err
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Left waiting room",
+	}, nil
+}
+
+// checkAdmission rejects a lock or booking request for a show with a waiting room unless it carries
+// the user's admission token; staff are never queued
+func (c *Controller) checkAdmission(r *http.Request, showID uint, userID uint) error {
+	ctx := r.Context()
+	if appcontext.IsStaff(ctx) {
+		return nil
+	}
+	return c.waitingRoom.CheckAdmission(ctx, showID, userID, helpers.ParseAdmissionToken(r))
+}
+
+// checkSeatAdmission is checkAdmission for the show the seat belongs to
+func (c *Controller) checkSeatAdmission(r *http.Request, seatID uint, userID uint) error {
+	ctx := r.Context()
+	if appcontext.IsStaff(ctx) {
+		return nil
+	}
+	return c.waitingRoom.CheckSeatAdmission(ctx, seatID, userID, helpers.ParseAdmissionToken(r))
+}
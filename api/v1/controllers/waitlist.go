@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"net/http"
+
+	"movie-booking/api/v1/helpers"
+	"movie-booking/api/v1/types"
+	appcontext "movie-booking/util/context"
+	"movie-booking/util/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// JoinWaitlistHandler handles POST /api/v1/shows/:id/waitlist
+func (c *Controller) JoinWaitlistHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[JoinWaitlist]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	// Parse and validate request
+	req, err := helpers.ValidateAndParseJoinWaitlistRequest(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID":    showID,
+		"seatCount": req.SeatCount,
+		"userID":    userID,
+	}).Info(TAG, "Join waitlist request")
+
+	// Call service layer
+	result, err := c.waitlist.JoinWaitlist(ctx, showID, userID, req.SeatCount)
+	if err != nil {
+		logger.WithError(err).Error(TAG, "Failed to join waitlist")
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"status":   result.Status,
+		"position": result.Position,
+	}).Info(TAG, "Joined waitlist")
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Joined waitlist",
+		Values:     result,
+	}, nil
+}
+
+// GetWaitlistStatusHandler handles GET /api/v1/shows/:id/waitlist
+func (c *Controller) GetWaitlistStatusHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[GetWaitlistStatus]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	// Call service layer
+	result, err := c.waitlist.GetWaitlistStatus(ctx, showID, userID)
+	if err != nil {
+		logger.WithError(err).Info(TAG, "Failed to get waitlist status")
+		return nil, err
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Waitlist status retrieved successfully",
+		Values:     result,
+	}, nil
+}
+
+// LeaveWaitlistHandler handles DELETE /api/v1/shows/:id/waitlist
+func (c *Controller) LeaveWaitlistHandler(w http.ResponseWriter, r *http.Request) (*types.GenericAPIResponse, error) {
+	TAG := "[LeaveWaitlist]"
+	ctx := r.Context()
+	logger := logrus.WithContext(ctx)
+
+	// Get user ID from context (set by auth interceptor)
+	userID, ok := appcontext.GetUserID(ctx)
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	// Parse show ID from path
+	showID, err := helpers.ParseShowIDFromPath(r)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid show ID")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"showID": showID,
+		"userID": userID,
+	}).Info(TAG, "Leave waitlist request")
+
+	// Call service layer
+	if err := c.waitlist.LeaveWaitlist(ctx, showID, userID); err != nil {
+		logger.WithError(err).Error(TAG, "Failed to leave waitlist")
+		return nil, err
+	}
+
+	return &types.GenericAPIResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Left waitlist",
+	}, nil
+}
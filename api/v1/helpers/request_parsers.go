@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"movie-booking/api/v1/types"
+	"movie-booking/constants"
+	"movie-booking/core/model"
 	"github.com/gorilla/mux"
 )
 
@@ -30,8 +34,10 @@ func ValidateAndParseLoginRequest(r *http.Request) (*types.LoginRequest, error)
 // ValidateAndParseBookingRequest parses and validates booking request
 func ValidateAndParseBookingRequest(r *http.Request, userID uint) (*types.CreateBookingInput, error) {
 	var req struct {
-		ShowID uint `json:"show_id"`
-		SeatID uint `json:"seat_id"`
+		ShowID  uint   `json:"show_id"`
+		HoldID  uint   `json:"hold_id"`
+		SeatID  uint   `json:"seat_id"`
+		SeatIDs []uint `json:"seat_ids"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -41,20 +47,253 @@ func ValidateAndParseBookingRequest(r *http.Request, userID uint) (*types.Create
 	if req.ShowID == 0 {
 		return nil, fmt.Errorf("show_id is required")
 	}
-	if req.SeatID == 0 {
-		return nil, fmt.Errorf("seat_id is required")
+
+	// A single seat_id is the original request shape
+	if req.SeatID != 0 {
+		req.SeatIDs = append(req.SeatIDs, req.SeatID)
+	}
+	if req.HoldID == 0 && len(req.SeatIDs) == 0 {
+		return nil, fmt.Errorf("hold_id or seat_ids is required")
+	}
+	if req.HoldID != 0 && len(req.SeatIDs) > 0 {
+		return nil, fmt.Errorf("provide either hold_id or seat_ids, not both")
+	}
+
+	if err := ValidateSeatIDs(req.SeatIDs); err != nil {
+		return nil, err
 	}
 
 	idempotencyKey := r.Header.Get("Idempotency-Key")
 
 	return &types.CreateBookingInput{
 		ShowID:         req.ShowID,
-		SeatID:         req.SeatID,
+		HoldID:         req.HoldID,
+		SeatIDs:        req.SeatIDs,
 		UserID:         userID,
 		IdempotencyKey: idempotencyKey,
 	}, nil
 }
 
+// ValidateAndParseLockSeatsRequest parses and validates a multi-seat lock request
+func ValidateAndParseLockSeatsRequest(r *http.Request) (*types.LockSeatsRequest, error) {
+	var req types.LockSeatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if len(req.SeatIDs) == 0 {
+		return nil, fmt.Errorf("seat_ids is required")
+	}
+
+	if err := ValidateSeatIDs(req.SeatIDs); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateSeatIDs rejects seat ID 0 and repeated seat IDs in a seat selection
+func ValidateSeatIDs(seatIDs []uint) error {
+	seen := make(map[uint]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		if id == 0 {
+			return fmt.Errorf("seat_ids must not contain 0")
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate seat_id: %d", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// ValidateAndParseBestAvailableRequest parses and validates a best-available seat request
+func ValidateAndParseBestAvailableRequest(r *http.Request) (*types.BestAvailableRequest, error) {
+	var req types.BestAvailableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if req.Quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be at least 1")
+	}
+
+	req.Category = strings.ToUpper(req.Category)
+	if req.Category != "" && !constants.IsValidSeatCategory(req.Category) {
+		return nil, fmt.Errorf("invalid category: %s", req.Category)
+	}
+
+	return &req, nil
+}
+
+// ValidateAndParseJoinWaitlistRequest parses and validates a request to join a show's waitlist
+func ValidateAndParseJoinWaitlistRequest(r *http.Request) (*types.JoinWaitlistRequest, error) {
+	var req types.JoinWaitlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if req.SeatCount <= 0 {
+		return nil, fmt.Errorf("seat_count must be at least 1")
+	}
+
+	return &req, nil
+}
+
+// maxBlockReasonLength matches the show_seats.block_reason column
+const maxBlockReasonLength = 255
+
+// ValidateAndParseBlockSeatsRequest parses and validates a per-show block or unblock; blocking needs a reason
+func ValidateAndParseBlockSeatsRequest(r *http.Request, block bool) (*types.BlockSeatsRequest, error) {
+	var req types.BlockSeatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if len(req.SeatIDs) == 0 {
+		return nil, fmt.Errorf("seat_ids is required")
+	}
+	if err := ValidateSeatIDs(req.SeatIDs); err != nil {
+		return nil, err
+	}
+
+	reason, err := validateBlockReason(req.Reason, block)
+	if err != nil {
+		return nil, err
+	}
+	req.Reason = reason
+
+	return &req, nil
+}
+
+// ValidateAndParseBlockScreenSeatsRequest parses and validates a screen-wide block or unblock; blocking needs a reason
+func ValidateAndParseBlockScreenSeatsRequest(r *http.Request, block bool) (*types.BlockScreenSeatsRequest, error) {
+	var req types.BlockScreenSeatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if len(req.SeatNames) == 0 {
+		return nil, fmt.Errorf("seat_names is required")
+	}
+	seen := make(map[string]bool, len(req.SeatNames))
+	for i, name := range req.SeatNames {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("seat_names must not contain empty names")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate seat name: %s", name)
+		}
+		seen[name] = true
+		req.SeatNames[i] = name
+	}
+
+	reason, err := validateBlockReason(req.Reason, block)
+	if err != nil {
+		return nil, err
+	}
+	req.Reason = reason
+
+	return &req, nil
+}
+
+// validateBlockReason trims the reason and checks it is present when blocking and fits the column
+func validateBlockReason(reason string, block bool) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if block && reason == "" {
+		return "", fmt.Errorf("reason is required")
+	}
+	if len(reason) > maxBlockReasonLength {
+		return "", fmt.Errorf("reason must be at most %d characters", maxBlockReasonLength)
+	}
+	return reason, nil
+}
+
+// ValidateAndParseWaitingRoomRequest parses and validates an admin waiting room toggle
+func ValidateAndParseWaitingRoomRequest(r *http.Request) (*types.WaitingRoomRequest, error) {
+	var req types.WaitingRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if req.Enabled == nil {
+		return nil, fmt.Errorf("enabled is required")
+	}
+
+	return &req, nil
+}
+
+// ParseShowFilterFromQuery parses the optional format, language and date filters for show listings
+func ParseShowFilterFromQuery(r *http.Request) (model.ShowFilter, error) {
+	query := r.URL.Query()
+	filter := model.ShowFilter{
+		Format:           strings.ToUpper(query.Get("format")),
+		AudioLanguage:    query.Get("language"),
+		SubtitleLanguage: query.Get("subtitles"),
+	}
+
+	if filter.Format != "" && !constants.IsValidShowFormat(filter.Format) {
+		return filter, fmt.Errorf("invalid format: %s", filter.Format)
+	}
+
+	if dateStr := query.Get("date"); dateStr != "" {
+		date, err := time.Parse(constants.DateLayout, dateStr)
+		if err != nil {
+			return filter, fmt.Errorf("invalid date, expected YYYY-MM-DD: %s", dateStr)
+		}
+		filter.Date = &date
+	}
+
+	return filter, nil
+}
+
+// ParseListBookingsRequest parses ?period=upcoming|past&cursor=&limit= for listing the user's bookings
+func ParseListBookingsRequest(r *http.Request) (*types.ListBookingsRequest, error) {
+	query := r.URL.Query()
+	req := &types.ListBookingsRequest{
+		Period: strings.ToLower(query.Get("period")),
+		Cursor: query.Get("cursor"),
+		Limit:  constants.DefaultBookingPageSize,
+	}
+
+	if req.Period != "" && !constants.IsValidBookingPeriod(req.Period) {
+		return nil, fmt.Errorf("invalid period, expected upcoming or past: %s", req.Period)
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > constants.MaxBookingPageSize {
+			return nil, fmt.Errorf("limit must be between 1 and %d", constants.MaxBookingPageSize)
+		}
+		req.Limit = limit
+	}
+
+	return req, nil
+}
+
+// ParseFlatSeatsFlag reports whether the client asked for the flat seat list (?flat=true) instead of the seat map
+func ParseFlatSeatsFlag(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("flat")
+	if value == "" {
+		return false, nil
+	}
+	flat, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid flat flag: %s", value)
+	}
+	return flat, nil
+}
+
+// ParseAdmissionToken returns the waiting room admission token from the X-Admission-Token header,
+// or from ?admission_token= for WebSocket clients that cannot set headers
+func ParseAdmissionToken(r *http.Request) string {
+	if token := r.Header.Get("X-Admission-Token"); token != "" {
+		return token
+	}
+	return r.URL.Query().Get("admission_token")
+}
+
 // ParseUintFromPath extracts a uint from URL path variable
 func ParseUintFromPath(r *http.Request, key string) (uint, error) {
 	vars := mux.Vars(r)
@@ -85,3 +324,23 @@ func ParseShowIDFromPath(r *http.Request) (uint, error) {
 func ParseSeatIDFromPath(r *http.Request) (uint, error) {
 	return ParseUintFromPath(r, "id")
 }
+
+// ParseScreenIDFromPath extracts screen ID from path
+func ParseScreenIDFromPath(r *http.Request) (uint, error) {
+	return ParseUintFromPath(r, "id")
+}
+
+// ParseBookingIDFromPath extracts booking ID from path
+func ParseBookingIDFromPath(r *http.Request) (uint, error) {
+	return ParseUintFromPath(r, "id")
+}
+
+// ParseOrderIDFromPath extracts order ID from path
+func ParseOrderIDFromPath(r *http.Request) (uint, error) {
+	return ParseUintFromPath(r, "id")
+}
+
+// ParseHoldIDFromPath extracts hold ID from path
+func ParseHoldIDFromPath(r *http.Request) (uint, error) {
+	return ParseUintFromPath(r, "id")
+}
@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ParseLastEventID reads the SSE resume position from the Last-Event-ID header or last_event_id query parameter
+func ParseLastEventID(r *http.Request) (uint64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Last-Event-ID: %w", err)
+	}
+	return id, nil
+}
+
+// WriteSSEEvent writes one Server-Sent Event with a JSON payload
+func WriteSSEEvent(w http.ResponseWriter, id uint64, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, strings.ToLower(event), payload)
+	return err
+}
+
+// WriteSSEComment writes an SSE comment line, used as a keep-alive heartbeat
+func WriteSSEComment(w http.ResponseWriter, comment string) error {
+	_, err := fmt.Fprintf(w, ": %s\n\n", comment)
+	return err
+}
@@ -1,11 +1,14 @@
 package interceptors
 
 import (
+	"bufio"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	"movie-booking/api/v1/helpers"
+	"movie-booking/constants"
 	appcontext "movie-booking/util/context"
 	"github.com/sirupsen/logrus"
 )
@@ -79,8 +82,15 @@ func AuthInterceptor(errorHandler func(error, http.ResponseWriter, *http.Request
 			}
 			userID := uint(userIDFloat)
 
-			// Set user ID in context
+			// Tokens issued before roles existed carry no role claim
+			role, ok := claims["role"].(string)
+			if !ok || role == "" {
+				role = string(constants.UserRoleCustomer)
+			}
+
+			// Set user ID and role in context
 			ctx := appcontext.SetUserID(r.Context(), userID)
+			ctx = appcontext.SetUserRole(ctx, role)
 			r = r.WithContext(ctx)
 
 			next(w, r)
@@ -88,6 +98,21 @@ func AuthInterceptor(errorHandler func(error, http.ResponseWriter, *http.Request
 	}
 }
 
+// QueryTokenInterceptor accepts the JWT from the access_token query parameter when no
+// Authorization header is sent, since browser WebSocket clients cannot set headers
+func QueryTokenInterceptor() Interceptor {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				if token := r.URL.Query().Get("access_token"); token != "" {
+					r.Header.Set("Authorization", "Bearer "+token)
+				}
+			}
+			next(w, r)
+		}
+	}
+}
+
 // PanicRecoveryInterceptor recovers from panics
 func PanicRecoveryInterceptor(errorHandler func(error, http.ResponseWriter, *http.Request)) Interceptor {
 	return func(next http.HandlerFunc) http.HandlerFunc {
@@ -114,3 +139,19 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Flush lets streaming handlers push data through the wrapper
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets WebSocket upgrades take over the connection through the wrapper
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
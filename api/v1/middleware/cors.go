@@ -10,8 +10,8 @@ func CORS(next http.Handler) http.Handler {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key")
-		w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key, X-Admission-Token, If-None-Match, If-Modified-Since")
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Authorization, ETag, Last-Modified")
 		w.Header().Set("Access-Control-Max-Age", "3600")
 
 		// Handle preflight requests
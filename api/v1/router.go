@@ -16,6 +16,8 @@ type Route struct {
 	Handler      http.HandlerFunc
 	SkipAuth     bool
 	DoNotLog     bool
+	Streaming    bool // Long-lived response; skips the handler timeout
+	QueryToken   bool // Also accept the JWT as ?access_token= (WebSocket clients)
 }
 
 // AddRoutesToRouter registers all routes with the router
@@ -50,6 +52,65 @@ func AddRoutesToRouter(router *mux.Router, ctrl *controllers.Controller) error {
 			SkipAuth:     true,
 			DoNotLog:     false,
 		},
+		{
+			Path:         "/api/v1/shows/{id}/seats/stream",
+			RequestMethod: http.MethodGet,
+			Handler:      ctrl.StreamSeatsHandler,
+			SkipAuth:     true,
+			DoNotLog:     false,
+			Streaming:    true,
+		},
+		{
+			Path:         "/api/v1/shows/{id}/ws",
+			RequestMethod: http.MethodGet,
+			Handler:      ctrl.SeatSocketHandler,
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+			Streaming:    true,
+			QueryToken:   true,
+		},
+		{
+			Path:         "/api/v1/shows/{id}/queue",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.JoinWaitingRoomHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/shows/{id}/queue",
+			RequestMethod: http.MethodGet,
+			Handler:      controllers.ResponseHandler(ctrl.GetWaitingRoomStatusHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     true,  // Polled while waiting
+		},
+		{
+			Path:         "/api/v1/shows/{id}/queue",
+			RequestMethod: http.MethodDelete,
+			Handler:      controllers.ResponseHandler(ctrl.LeaveWaitingRoomHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/shows/{id}/waitlist",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.JoinWaitlistHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/shows/{id}/waitlist",
+			RequestMethod: http.MethodGet,
+			Handler:      controllers.ResponseHandler(ctrl.GetWaitlistStatusHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     true,  // Polled while waiting for an offer
+		},
+		{
+			Path:         "/api/v1/shows/{id}/waitlist",
+			RequestMethod: http.MethodDelete,
+			Handler:      controllers.ResponseHandler(ctrl.LeaveWaitlistHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
 		{
 			Path:         "/api/v1/seats/{id}/lock",
 			RequestMethod: http.MethodPatch,
@@ -57,6 +118,41 @@ func AddRoutesToRouter(router *mux.Router, ctrl *controllers.Controller) error {
 			SkipAuth:     false, // Requires auth
 			DoNotLog:     false,
 		},
+		{
+			Path:         "/api/v1/seats/{id}/lock",
+			RequestMethod: http.MethodDelete,
+			Handler:      controllers.ResponseHandler(ctrl.ReleaseSeatHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/holds/{id}",
+			RequestMethod: http.MethodDelete,
+			Handler:      controllers.ResponseHandler(ctrl.ReleaseHoldHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/holds/{id}/renew",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.RenewHoldHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/shows/{id}/locks",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.LockSeatsHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/shows/{id}/best-available",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.BestAvailableHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
 		{
 			Path:         "/api/v1/bookings",
 			RequestMethod: http.MethodPost,
@@ -64,6 +160,90 @@ func AddRoutesToRouter(router *mux.Router, ctrl *controllers.Controller) error {
 			SkipAuth:     false, // Requires auth
 			DoNotLog:     false,
 		},
+		{
+			Path:         "/api/v1/bookings",
+			RequestMethod: http.MethodGet,
+			Handler:      controllers.ResponseHandler(ctrl.ListBookingsHandler),
+			SkipAuth:     false, // Requires auth
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/bookings/{id}",
+			RequestMethod: http.MethodGet,
+			Handler:      controllers.ResponseHandler(ctrl.GetBookingHandler),
+			SkipAuth:     false, // Requires auth (staff may view any booking)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/bookings/{id}/cancel",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.CancelBookingHandler),
+			SkipAuth:     false, // Requires auth (staff may cancel any booking)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/shows/{id}/waiting-room",
+			RequestMethod: http.MethodPut,
+			Handler:      controllers.ResponseHandler(ctrl.SetWaitingRoomHandler),
+			SkipAuth:     false, // Requires auth (admin role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/bookings/{id}/check-in",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.CheckInBookingHandler),
+			SkipAuth:     false, // Requires auth (staff role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/bookings/{id}/refund",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.MarkBookingRefundedHandler),
+			SkipAuth:     false, // Requires auth (staff role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/orders/{id}/payment",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.ConfirmOrderPaymentHandler),
+			SkipAuth:     false, // Requires auth (staff role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/seats/{id}/events",
+			RequestMethod: http.MethodGet,
+			Handler:      controllers.ResponseHandler(ctrl.GetSeatTimelineHandler),
+			SkipAuth:     false, // Requires auth (staff role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/shows/{id}/seats/block",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.BlockShowSeatsHandler),
+			SkipAuth:     false, // Requires auth (admin role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/shows/{id}/seats/unblock",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.UnblockShowSeatsHandler),
+			SkipAuth:     false, // Requires auth (admin role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/screens/{id}/seats/block",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.BlockScreenSeatsHandler),
+			SkipAuth:     false, // Requires auth (admin role checked in handler)
+			DoNotLog:     false,
+		},
+		{
+			Path:         "/api/v1/admin/screens/{id}/seats/unblock",
+			RequestMethod: http.MethodPost,
+			Handler:      controllers.ResponseHandler(ctrl.UnblockScreenSeatsHandler),
+			SkipAuth:     false, // Requires auth (admin role checked in handler)
+			DoNotLog:     false,
+		},
 	}
 
 	// Register each route
@@ -75,6 +255,9 @@ func AddRoutesToRouter(router *mux.Router, ctrl *controllers.Controller) error {
 		}
 
 		// Add auth interceptor if required
+		if !route.SkipAuth && route.QueryToken {
+			interceptorChain = append(interceptorChain, interceptors.QueryTokenInterceptor())
+		}
 		if !route.SkipAuth {
 			interceptorChain = append(interceptorChain,
 				interceptors.AuthInterceptor(controllers.ErrorHandler))
@@ -83,6 +266,12 @@ func AddRoutesToRouter(router *mux.Router, ctrl *controllers.Controller) error {
 		// Apply interceptors
 		handler := interceptors.Intercept(route.Handler, interceptorChain...)
 
+		// Streaming responses stay open indefinitely and need http.Flusher, which TimeoutHandler hides
+		if route.Streaming {
+			router.Handle(route.Path, handler).Methods(route.RequestMethod)
+			continue
+		}
+
 		// Add timeout handler
 		timeout := config.GetHandlerTimeout()
 		timeoutHandler := http.TimeoutHandler(handler, timeout, `{"success":false,"message":"Request timeout"}`)
@@ -3,6 +3,9 @@ package types
 import (
 	"net/http"
 	"time"
+
+	"movie-booking/core/events"
+	"movie-booking/core/model"
 )
 
 // HandlerFunc is the signature for handler functions
@@ -13,6 +16,7 @@ type GenericAPIResponse struct {
 	Success    bool        `json:"success"`
 	StatusCode int         `json:"statusCode"`
 	Message    string      `json:"message,omitempty"`
+	ErrorCode  string      `json:"errorCode,omitempty"`
 	Values     interface{} `json:"values,omitempty"`
 	Error      []FieldError `json:"error,omitempty"`
 }
@@ -40,25 +44,322 @@ type UserInfo struct {
 	ID    uint   `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	Role  string `json:"role"`
 }
 
 // LockSeatResponse represents the response for locking a seat
 type LockSeatResponse struct {
 	Message   string    `json:"message"`
+	HoldID    uint      `json:"hold_id"`
+	SeatIDs   []uint    `json:"seat_ids,omitempty"`
+	SeatNames []string  `json:"seat_names,omitempty"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
-// CreateBookingInput represents the input for creating a booking
+// LockSeatsRequest represents the request for locking several seats at once
+type LockSeatsRequest struct {
+	SeatIDs []uint `json:"seat_ids"`
+}
+
+// BestAvailableRequest represents the request for automatic seat selection
+type BestAvailableRequest struct {
+	Quantity int    `json:"quantity"`
+	Category string `json:"category,omitempty"`
+}
+
+// ReleaseSeatResponse represents the response for releasing a seat or hold
+type ReleaseSeatResponse struct {
+	Message string `json:"message"`
+	SeatIDs []uint `json:"seat_ids"`
+}
+
+// SeatMapResponse is the structured seat map of a show
+type SeatMapResponse struct {
+	ShowID     uint                 `json:"show_id"`
+	Version    uint64               `json:"version"` // Changes on every seat mutation; also served as the ETag
+	Screen     SeatMapScreen        `json:"screen"`
+	Columns    int                  `json:"columns"`    // Grid width shared by every row
+	Positioned bool                 `json:"positioned"` // False when seats lack or share a row/number and are listed in order
+	Rows       []SeatMapRow         `json:"rows"`       // Nearest the screen first
+	Legend     []SeatMapLegendEntry `json:"legend"`
+}
+
+// SeatMapScreen marks where the screen sits relative to the rows
+type SeatMapScreen struct {
+	Position string `json:"position"` // FRONT: in front of the first row
+	Label    string `json:"label"`
+}
+
+// SeatMapRow is one row of the seat map, with a cell for every grid column
+type SeatMapRow struct {
+	Label string        `json:"label"`
+	Index int           `json:"index"` // 0 is the row nearest the screen
+	Cells []SeatMapCell `json:"cells"`
+}
+
+// SeatMapCell is a grid column of a row: a seat, a gap in this row, or an aisle running through every row
+type SeatMapCell struct {
+	Type   string       `json:"type"`   // SEAT, GAP, AISLE
+	Column int          `json:"column"` // 1-based, equal to the seat number for seats of a positioned map
+	Seat   *SeatMapSeat `json:"seat,omitempty"`
+}
+
+// SeatMapSeat is a seat in the structured seat map
+type SeatMapSeat struct {
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	Number    int        `json:"number"`
+	Category  string     `json:"category"`
+	Price     float64    `json:"price"`
+	Status    string     `json:"status"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // When a LOCKED seat is released
+	BlockReason string   `json:"block_reason,omitempty"` // Why a BLOCKED seat is not for sale
+}
+
+// SeatMapLegendEntry explains a seat category (with its price) or a seat status shown on the map
+type SeatMapLegendEntry struct {
+	Kind  string   `json:"kind"`  // CATEGORY or STATUS
+	Value string   `json:"value"` // e.g. PREMIUM or SOLD
+	Label string   `json:"label"`
+	Price *float64 `json:"price,omitempty"`
+}
+
+// BlockSeatsRequest blocks or unblocks seats of one show; a reason is required to block
+type BlockSeatsRequest struct {
+	SeatIDs []uint `json:"seat_ids"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// BlockScreenSeatsRequest blocks or unblocks seats by name on every upcoming show of a screen
+type BlockScreenSeatsRequest struct {
+	SeatNames []string `json:"seat_names"`
+	Reason    string   `json:"reason,omitempty"`
+}
+
+// SeatBlockResponse reports which seats were blocked or unblocked
+type SeatBlockResponse struct {
+	Message string        `json:"message"`
+	SeatIDs []uint        `json:"seat_ids"`
+	ShowIDs []uint        `json:"show_ids"`
+	Skipped []SkippedSeat `json:"skipped,omitempty"` // Screen-wide changes leave sold, held or already-matching seats alone
+}
+
+// SkippedSeat is a seat a screen-wide block or unblock left unchanged
+type SkippedSeat struct {
+	SeatID   uint   `json:"seat_id"`
+	ShowID   uint   `json:"show_id"`
+	SeatName string `json:"seat_name"`
+	Reason   string `json:"reason"`
+}
+
+// SeatTimelineResponse is a seat's current state and its audit history, oldest first
+type SeatTimelineResponse struct {
+	Seat   model.ShowSeat    `json:"seat"`
+	Events []model.SeatEvent `json:"events"`
+}
+
+// CreateBookingInput represents the input for creating a booking: every seat of a hold, or a list of held seats
 type CreateBookingInput struct {
 	ShowID         uint   `json:"show_id"`
-	SeatID         uint   `json:"seat_id"`
+	HoldID         uint   `json:"hold_id"`
+	SeatIDs        []uint `json:"seat_ids"` // seat_id is accepted for a single seat
 	UserID         uint   // Set from JWT
 	IdempotencyKey string // From header
 }
 
-// BookingResponse represents the response for a booking
+// BookingResponse represents the response for a booking: an order with a line item per seat
 type BookingResponse struct {
-	BookingID uint   `json:"booking_id"`
-	Status    string `json:"status"`
-	Message   string `json:"message"`
+	OrderID      uint              `json:"order_id"`
+	BookingID    uint              `json:"booking_id"` // First line item, for clients that book one seat
+	Status       string            `json:"status"`
+	Amount       float64           `json:"amount"` // Order total
+	Items        []BookingLineItem `json:"items"`
+	PaymentDueAt *time.Time        `json:"payment_due_at,omitempty"` // Set while the order awaits payment
+	Message      string            `json:"message"`
+}
+
+// BookingLineItem is the booking of one seat within an order
+type BookingLineItem struct {
+	BookingID uint    `json:"booking_id"`
+	Status    string  `json:"status"`
+	SeatID    uint    `json:"seat_id"`
+	SeatName  string  `json:"seat_name"`
+	Amount    float64 `json:"amount"`
+}
+
+// CancelBookingResponse represents the response for a booking cancellation
+type CancelBookingResponse struct {
+	BookingID     uint      `json:"booking_id"`
+	OrderID       uint      `json:"order_id"`
+	Status        string    `json:"status"`
+	Amount        float64   `json:"amount"`
+	RefundAmount  float64   `json:"refund_amount"`  // Owed to the user
+	RefundPercent int       `json:"refund_percent"` // Share of amount refunded under the theatre's policy
+	CancelledAt   time.Time `json:"cancelled_at"`
+	Message       string    `json:"message"`
+}
+
+// BookingStatusResponse represents the response for a staff change of booking status
+type BookingStatusResponse struct {
+	BookingID  uint   `json:"booking_id"`
+	OrderID    uint   `json:"order_id"`
+	FromStatus string `json:"from_status"`
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+}
+
+// BookingStatusChange is one entry of a booking's status history
+type BookingStatusChange struct {
+	FromStatus string    `json:"from_status,omitempty"` // Empty when the booking was created
+	Status     string    `json:"status"`
+	Reason     string    `json:"reason,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// ListBookingsRequest selects a page of the user's bookings
+type ListBookingsRequest struct {
+	Period string // upcoming, past or empty for all
+	Cursor string // next_cursor of the previous page
+	Limit  int
+}
+
+// BookingListResponse is a page of the user's bookings
+type BookingListResponse struct {
+	Bookings   []BookingSummary `json:"bookings"`
+	NextCursor string           `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// BookingSummary is a booking with the details needed to show it to its user
+type BookingSummary struct {
+	BookingID uint           `json:"booking_id"`
+	OrderID   uint           `json:"order_id"`
+	Status    string         `json:"status"`
+	Amount    float64        `json:"amount"`
+	BookedAt  time.Time      `json:"booked_at"`
+	Show      BookingShow    `json:"show"`
+	Movie     BookingMovie   `json:"movie"`
+	Theatre   BookingTheatre `json:"theatre"`
+	Seat      BookingSeat    `json:"seat"`
+}
+
+// BookingDetailResponse is a booking with its seats, show, theatre and how its amount was made up
+type BookingDetailResponse struct {
+	BookingID uint           `json:"booking_id"`
+	OrderID   uint           `json:"order_id"`
+	UserID    uint           `json:"user_id"`
+	Status    string         `json:"status"`
+	BookedAt  time.Time      `json:"booked_at"`
+	Show      BookingShow    `json:"show"`
+	Movie     BookingMovie   `json:"movie"`
+	Theatre   BookingTheatre `json:"theatre"`
+	Seats     []BookingSeat  `json:"seats"`
+	Price     BookingPrice   `json:"price"`
+
+	RefundAmount *float64   `json:"refund_amount,omitempty"` // Set once cancelled
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+
+	History []BookingStatusChange `json:"history"` // Every status the booking has had, oldest first
+}
+
+// BookingPrice splits the amount charged for a booking
+type BookingPrice struct {
+	BasePrice       float64 `json:"base_price"`
+	FormatSurcharge float64 `json:"format_surcharge"` // Extra charge for a premium format such as IMAX
+	Total           float64 `json:"total"`            // The amount charged
+}
+
+// BookingShow describes the show a booking is for
+type BookingShow struct {
+	ID               uint      `json:"id"`
+	StartTime        time.Time `json:"start_time"`
+	LocalStartTime   string    `json:"local_start_time"` // start_time in the theatre's time zone
+	Format           string    `json:"format"`
+	AudioLanguage    string    `json:"audio_language"`
+	SubtitleLanguage *string   `json:"subtitle_language,omitempty"`
+	Screen           string    `json:"screen,omitempty"`
+}
+
+// BookingMovie describes the movie of a booked show
+type BookingMovie struct {
+	ID           uint   `json:"id"`
+	Title        string `json:"title"`
+	DurationMins int    `json:"duration_mins"`
+	Rating       string `json:"rating"`
+}
+
+// BookingTheatre describes the theatre of a booked show
+type BookingTheatre struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+// BookingSeat describes a booked seat
+type BookingSeat struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Row      string `json:"row"`
+	Number   int    `json:"number"`
+	Category string `json:"category"`
+}
+
+// WaitingRoomRequest switches a show's waiting room on or off
+type WaitingRoomRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// WaitingRoomSettingsResponse reports a show's waiting room setting
+type WaitingRoomSettingsResponse struct {
+	ShowID      uint `json:"show_id"`
+	Enabled     bool `json:"enabled"`
+	MaxAdmitted int  `json:"max_admitted"`
+	Admitted    int  `json:"admitted"`
+	Queued      int  `json:"queued"`
+}
+
+// WaitingRoomStatusResponse tells a user their place in a show's waiting room
+type WaitingRoomStatusResponse struct {
+	ShowID               uint       `json:"show_id"`
+	State                string     `json:"state"`              // OPEN, QUEUED or ADMITTED
+	Position             int        `json:"position,omitempty"` // 1 is next in line
+	QueueLength          int        `json:"queue_length"`
+	EstimatedWaitSeconds int        `json:"estimated_wait_seconds"`
+	AdmissionToken       string     `json:"admission_token,omitempty"` // Send as X-Admission-Token when locking and booking
+	AdmittedUntil        *time.Time `json:"admitted_until,omitempty"`
+}
+
+// JoinWaitlistRequest puts the user on a show's waitlist for a number of adjacent seats
+type JoinWaitlistRequest struct {
+	SeatCount int `json:"seat_count"`
+}
+
+// WaitlistStatusResponse tells a user where they stand on a show's waitlist, and what is held for them once offered
+type WaitlistStatusResponse struct {
+	ShowID         uint       `json:"show_id"`
+	Status         string     `json:"status"` // WAITING, OFFERED, CLAIMED, EXPIRED or LEFT
+	SeatCount      int        `json:"seat_count"`
+	Position       int        `json:"position,omitempty"` // 1 is next in line, while WAITING
+	QueueLength    int        `json:"queue_length"`
+	HoldID         uint       `json:"hold_id,omitempty"` // Book the offered seats under this hold before it expires
+	SeatIDs        []uint     `json:"seat_ids,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+}
+
+// SeatSocketCommand is a message sent by a client over the seat WebSocket
+type SeatSocketCommand struct {
+	Type      string `json:"type"` // lock, release
+	RequestID string `json:"request_id,omitempty"`
+	SeatID    uint   `json:"seat_id,omitempty"`
+	SeatIDs   []uint `json:"seat_ids,omitempty"`
+	HoldID    uint   `json:"hold_id,omitempty"`
+}
+
+// SeatSocketMessage is a message pushed to clients over the seat WebSocket
+type SeatSocketMessage struct {
+	Type      string            `json:"type"` // seat, result, error
+	RequestID string            `json:"request_id,omitempty"`
+	Event     *events.SeatEvent `json:"event,omitempty"`
+	Values    interface{}       `json:"values,omitempty"`
+	ErrorCode string            `json:"errorCode,omitempty"`
+	Message   string            `json:"message,omitempty"`
 }
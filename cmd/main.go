@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Embed the zone database so theatre time zones resolve in minimal containers
 
 	"movie-booking/api/v1"
 	"movie-booking/api/v1/controllers"
 	"movie-booking/api/v1/middleware"
 	"movie-booking/config"
+	"movie-booking/core/events"
 	"movie-booking/core/services"
 	coretypes "movie-booking/core/types"
 	"movie-booking/datastore"
@@ -65,7 +73,8 @@ func main() {
 }
 
 func initDatabase() (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	// Store and read all timestamps in UTC regardless of server or MySQL time zone
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%%27%%2B00%%3A00%%27",
 		config.GetDatabaseUser(),
 		config.GetDatabasePassword(),
 		config.GetDatabaseHost(),
@@ -96,8 +105,22 @@ func startAPIServer(db *gorm.DB) {
 	// Create datastore
 	store := datastore.NewDataStore(db)
 
+	// Create the seat hold backend
+	seatLocks, err := services.NewSeatLockManager(config.GetSeatLockBackend())
+	if err != nil {
+		log.Fatalf("Failed to create seat lock manager: %v", err)
+	}
+
 	// Create clients (for dependency injection)
-	clients := &coretypes.Clients{}
+	clients := &coretypes.Clients{
+		SeatEvents: events.NewHub(config.GetSeatEventHistorySize(), config.GetSeatEventHistoryTTL(), config.GetSeatEventBufferSize()),
+		SeatLocks:  seatLocks,
+		Notifier:   services.NewLogNotifier(),
+	}
+
+	// Freed seats are offered to waitlisted users; the other services report releases to it, so it is wired in first
+	waitlistOfferer := services.NewWaitlistOfferer(clients, store)
+	clients.Waitlist = waitlistOfferer
 
 	// Create services
 	authService := services.NewAuthService(clients, store)
@@ -105,6 +128,16 @@ func startAPIServer(db *gorm.DB) {
 	showService := services.NewShowService(clients, store)
 	seatService := services.NewSeatService(clients, store)
 	bookingService := services.NewBookingService(clients, store)
+	waitingRoomService := services.NewWaitingRoomService(clients, store)
+	waitlistService := services.NewWaitlistService(clients, store)
+
+	// Background workers and the server stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Expire seat locks and unpaid orders in the background
+	services.StartBackgroundWorkers(ctx, clients, store)
+	waitlistOfferer.Start(ctx)
 
 	// Create controller
 	ctrl := controllers.NewController(
@@ -113,6 +146,8 @@ func startAPIServer(db *gorm.DB) {
 		showService,
 		seatService,
 		bookingService,
+		waitingRoomService,
+		waitlistService,
 	)
 
 	// Create router
@@ -141,7 +176,31 @@ func startAPIServer(db *gorm.DB) {
 	port := config.GetServerPort()
 	logrus.WithField("port", port).Info("Starting API server")
 	
-	if err := http.ListenAndServe(":"+port, handler); err != nil {
+	// Requests run under a base context cancelled as shutdown starts: Shutdown waits for open requests, and SSE
+	// and WebSocket streams only end when their context does
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	server := &http.Server{
+		Addr:        ":" + port,
+		Handler:     handler,
+		BaseContext: func(net.Listener) context.Context { return requestCtx },
+	}
+	server.RegisterOnShutdown(cancelRequests)
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		logrus.Info("Shutting down API server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logrus.WithError(err).Error("API server shutdown failed")
+		}
+	}()
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+	// ListenAndServe returns as soon as Shutdown starts, so wait for open requests to finish
+	<-shutdownDone
 }
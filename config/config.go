@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/viper"
@@ -25,6 +26,45 @@ func Init() error {
 	settings.SetDefault("JWT_SECRET", "change-me-in-production")
 	settings.SetDefault("JWT_EXPIRY", "15m")
 	settings.SetDefault("SEAT_LOCK_DURATION", "10m")
+	settings.SetDefault("SEAT_LOCK_BACKEND", "mysql")
+	settings.SetDefault("MAX_SEATS_PER_HOLD", 10)
+	settings.SetDefault("SEAT_HOLD_MAX_DURATION", "20m")
+	settings.SetDefault("SEAT_HOLD_MAX_EXTENSIONS", 2)
+	settings.SetDefault("MAX_SEATS_PER_USER_PER_SHOW", 10)
+	settings.SetDefault("MAX_CONCURRENT_HOLDS_PER_USER", 3)
+	settings.SetDefault("SEAT_EVENT_HISTORY_SIZE", 500)
+	settings.SetDefault("SEAT_EVENT_HISTORY_TTL", "30m")
+	settings.SetDefault("SEAT_EVENT_BUFFER_SIZE", 64)
+	settings.SetDefault("SEAT_STREAM_HEARTBEAT_INTERVAL", "15s")
+	settings.SetDefault("SEAT_SOCKET_PING_INTERVAL", "20s")
+	settings.SetDefault("SEAT_SOCKET_PONG_TIMEOUT", "60s")
+	settings.SetDefault("SEAT_SOCKET_WRITE_TIMEOUT", "10s")
+	settings.SetDefault("SEAT_SOCKET_SEND_BUFFER_SIZE", 64)
+	settings.SetDefault("SEAT_SOCKET_HOLD_RELEASE_GRACE", "30s")
+	settings.SetDefault("SEAT_LOCK_SWEEPER_ENABLED", true)
+	settings.SetDefault("SEAT_LOCK_SWEEP_INTERVAL", "30s")
+	settings.SetDefault("SEAT_LOCK_SWEEP_BATCH_SIZE", 100)
+	settings.SetDefault("SALES_CLOSE_BEFORE_START", "0m")
+	settings.SetDefault("BOOKING_PAYMENT_TIMEOUT", "0m")
+	settings.SetDefault("BOOKING_PAYMENT_EXPIRY_INTERVAL", "30s")
+	settings.SetDefault("WAITING_ROOM_MAX_ADMITTED", 100)
+	settings.SetDefault("WAITING_ROOM_ADMISSION_TTL", "15m")
+	settings.SetDefault("WAITING_ROOM_IDLE_TIMEOUT", "2m")
+	settings.SetDefault("WAITLIST_OFFER_INTERVAL", "15s")
+
+	// The sweeper loops until a batch comes back short, so it needs a positive batch size
+	if size := GetSeatLockSweepBatchSize(); size < 1 {
+		return fmt.Errorf("SEAT_LOCK_SWEEP_BATCH_SIZE must be at least 1, got %d", size)
+	}
+	if interval := GetSeatLockSweepInterval(); interval <= 0 {
+		return fmt.Errorf("SEAT_LOCK_SWEEP_INTERVAL must be positive, got %s", interval)
+	}
+	if timeout := GetBookingPaymentTimeout(); timeout < 0 {
+		return fmt.Errorf("BOOKING_PAYMENT_TIMEOUT must not be negative, got %s", timeout)
+	}
+	if interval := GetBookingPaymentExpiryInterval(); interval <= 0 {
+		return fmt.Errorf("BOOKING_PAYMENT_EXPIRY_INTERVAL must be positive, got %s", interval)
+	}
 
 	return nil
 }
@@ -77,3 +117,122 @@ func GetJWTExpiry() time.Duration {
 func GetSeatLockDuration() time.Duration {
 	return settings.GetDuration("SEAT_LOCK_DURATION")
 }
+
+// GetSeatLockBackend returns how seat holds are kept: "mysql" (row locks), "optimistic" (versioned updates) or "memory" (single node)
+func GetSeatLockBackend() string {
+	return settings.GetString("SEAT_LOCK_BACKEND")
+}
+
+func GetMaxSeatsPerHold() int {
+	return settings.GetInt("MAX_SEATS_PER_HOLD")
+}
+
+func GetSeatHoldMaxDuration() time.Duration {
+	return settings.GetDuration("SEAT_HOLD_MAX_DURATION")
+}
+
+func GetSeatHoldMaxExtensions() int {
+	return settings.GetInt("SEAT_HOLD_MAX_EXTENSIONS")
+}
+
+// Per-user seat quota configuration
+func GetMaxSeatsPerUserPerShow() int {
+	return settings.GetInt("MAX_SEATS_PER_USER_PER_SHOW")
+}
+
+// GetMaxConcurrentHoldsPerUser limits how many shows a user can hold seats for at once. Despite the name it does
+// not count holds: any number of holds on one show counts once.
+func GetMaxConcurrentHoldsPerUser() int {
+	return settings.GetInt("MAX_CONCURRENT_HOLDS_PER_USER")
+}
+
+// Seat event streaming configuration
+func GetSeatEventHistorySize() int {
+	return settings.GetInt("SEAT_EVENT_HISTORY_SIZE")
+}
+
+// GetSeatEventHistoryTTL is how long a show's event history outlives its last event and subscriber
+func GetSeatEventHistoryTTL() time.Duration {
+	return settings.GetDuration("SEAT_EVENT_HISTORY_TTL")
+}
+
+func GetSeatEventBufferSize() int {
+	return settings.GetInt("SEAT_EVENT_BUFFER_SIZE")
+}
+
+func GetSeatStreamHeartbeatInterval() time.Duration {
+	return settings.GetDuration("SEAT_STREAM_HEARTBEAT_INTERVAL")
+}
+
+// Seat WebSocket configuration
+func GetSeatSocketPingInterval() time.Duration {
+	return settings.GetDuration("SEAT_SOCKET_PING_INTERVAL")
+}
+
+func GetSeatSocketPongTimeout() time.Duration {
+	return settings.GetDuration("SEAT_SOCKET_PONG_TIMEOUT")
+}
+
+func GetSeatSocketWriteTimeout() time.Duration {
+	return settings.GetDuration("SEAT_SOCKET_WRITE_TIMEOUT")
+}
+
+func GetSeatSocketSendBufferSize() int {
+	return settings.GetInt("SEAT_SOCKET_SEND_BUFFER_SIZE")
+}
+
+func GetSeatSocketHoldReleaseGrace() time.Duration {
+	return settings.GetDuration("SEAT_SOCKET_HOLD_RELEASE_GRACE")
+}
+
+// Seat lock sweeper configuration
+func GetSeatLockSweeperEnabled() bool {
+	return settings.GetBool("SEAT_LOCK_SWEEPER_ENABLED")
+}
+
+func GetSeatLockSweepInterval() time.Duration {
+	return settings.GetDuration("SEAT_LOCK_SWEEP_INTERVAL")
+}
+
+func GetSeatLockSweepBatchSize() int {
+	return settings.GetInt("SEAT_LOCK_SWEEP_BATCH_SIZE")
+}
+
+// Sales window configuration
+func GetSalesCloseBeforeStart() time.Duration {
+	return settings.GetDuration("SALES_CLOSE_BEFORE_START")
+}
+
+// GetBookingPaymentTimeout returns how long a new order may await payment before it expires and its seats
+// go back on sale; zero confirms orders at purchase
+func GetBookingPaymentTimeout() time.Duration {
+	return settings.GetDuration("BOOKING_PAYMENT_TIMEOUT")
+}
+
+// GetBookingPaymentExpiryInterval returns how often orders are checked for an overdue payment
+func GetBookingPaymentExpiryInterval() time.Duration {
+	return settings.GetDuration("BOOKING_PAYMENT_EXPIRY_INTERVAL")
+}
+
+// Waiting room configuration
+func GetWaitingRoomMaxAdmitted() int {
+	return settings.GetInt("WAITING_ROOM_MAX_ADMITTED")
+}
+
+// GetWaitingRoomAdmissionTTL returns how long an admitted session may lock and book before it must queue again
+func GetWaitingRoomAdmissionTTL() time.Duration {
+	return settings.GetDuration("WAITING_ROOM_ADMISSION_TTL")
+}
+
+// GetWaitingRoomIdleTimeout returns how long a queued user may go without polling before losing their place
+func GetWaitingRoomIdleTimeout() time.Duration {
+	return settings.GetDuration("WAITING_ROOM_IDLE_TIMEOUT")
+}
+
+// Waitlist configuration
+
+// GetWaitlistOfferInterval returns how often waitlists are re-checked for lapsed offers and free seats,
+// on top of the immediate check whenever seats are released
+func GetWaitlistOfferInterval() time.Duration {
+	return settings.GetDuration("WAITLIST_OFFER_INTERVAL")
+}
@@ -0,0 +1,39 @@
+package constants
+
+// BookingStatus represents the status of a booking
+type BookingStatus string
+
+const (
+	BookingStatusPendingPayment BookingStatus = "PENDING_PAYMENT" // Seat sold, awaiting payment
+	BookingStatusConfirmed      BookingStatus = "CONFIRMED"
+	BookingStatusCancelled      BookingStatus = "CANCELLED" // Seat back on sale, refund_amount owed
+	BookingStatusRefunded       BookingStatus = "REFUNDED"  // refund_amount paid out
+	BookingStatusCheckedIn      BookingStatus = "CHECKED_IN"
+	BookingStatusExpired        BookingStatus = "EXPIRED" // Payment never arrived, seat back on sale
+)
+
+// InactiveBookingStatuses no longer hold a seat: they count towards neither seat quotas nor an order being live
+var InactiveBookingStatuses = []string{
+	string(BookingStatusCancelled),
+	string(BookingStatusRefunded),
+	string(BookingStatusExpired),
+}
+
+// BookingPeriod selects bookings by whether their show has started
+type BookingPeriod string
+
+const (
+	BookingPeriodUpcoming BookingPeriod = "upcoming" // Show starts in the future, soonest first
+	BookingPeriodPast     BookingPeriod = "past"     // Show has started, most recent first
+)
+
+// IsValidBookingPeriod checks whether the given period filter is supported
+func IsValidBookingPeriod(period string) bool {
+	return period == string(BookingPeriodUpcoming) || period == string(BookingPeriodPast)
+}
+
+// Page sizes for listing bookings
+const (
+	DefaultBookingPageSize = 20
+	MaxBookingPageSize     = 100
+)
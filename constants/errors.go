@@ -0,0 +1,28 @@
+package constants
+
+// Error codes returned in the errorCode field of API error responses
+const (
+	ErrCodeBadRequest = "BAD_REQUEST"
+
+	ErrCodeSalesNotOpen = "SALES_NOT_OPEN"
+	ErrCodeSalesClosed  = "SALES_CLOSED"
+
+	ErrCodeSeatUnavailable  = "SEAT_UNAVAILABLE"
+	ErrCodeSeatNotLocked    = "SEAT_NOT_LOCKED"
+	ErrCodeNotSeatHolder    = "NOT_SEAT_HOLDER"
+	ErrCodeHoldExpired      = "HOLD_EXPIRED"
+	ErrCodeHoldLimit        = "HOLD_LIMIT_REACHED"
+	ErrCodeNoSeatsAvailable = "NO_SEATS_AVAILABLE"
+	ErrCodeSingleSeatGap    = "SINGLE_SEAT_GAP"
+
+	ErrCodeSeatQuotaExceeded = "SEAT_QUOTA_EXCEEDED"
+	ErrCodeHoldQuotaExceeded = "HOLD_QUOTA_EXCEEDED"
+
+	ErrCodeAdmissionRequired = "ADMISSION_REQUIRED"
+	ErrCodeNotInWaitingRoom  = "NOT_IN_WAITING_ROOM"
+
+	ErrCodeNotOnWaitlist = "NOT_ON_WAITLIST"
+
+	ErrCodeInvalidBookingTransition = "INVALID_BOOKING_TRANSITION"
+	ErrCodeCancellationClosed       = "CANCELLATION_CLOSED"
+)
@@ -0,0 +1,11 @@
+package constants
+
+// ScreenLayout selects how seats in a screen are ranked for best-available selection
+type ScreenLayout string
+
+const (
+	// ScreenLayoutStandard favours centre seats a little behind the middle of the hall
+	ScreenLayoutStandard ScreenLayout = "STANDARD"
+	// ScreenLayoutLargeFormat favours rows further back, where a tall screen fills the view
+	ScreenLayoutLargeFormat ScreenLayout = "LARGE_FORMAT"
+)
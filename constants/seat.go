@@ -7,6 +7,7 @@ const (
 	SeatStatusAvailable SeatStatus = "AVAILABLE"
 	SeatStatusLocked    SeatStatus = "LOCKED"
 	SeatStatusSold      SeatStatus = "SOLD"
+	SeatStatusBlocked   SeatStatus = "BLOCKED" // Taken out of sale by staff (broken, press, house seats)
 )
 
 // ValidSeatStatuses returns all valid seat statuses
@@ -14,4 +15,96 @@ var ValidSeatStatuses = []SeatStatus{
 	SeatStatusAvailable,
 	SeatStatusLocked,
 	SeatStatusSold,
+	SeatStatusBlocked,
 }
+
+// SeatCategory represents the pricing category of a seat
+type SeatCategory string
+
+const (
+	SeatCategoryStandard SeatCategory = "STANDARD"
+	SeatCategoryPremium  SeatCategory = "PREMIUM"
+	SeatCategoryRecliner SeatCategory = "RECLINER"
+)
+
+// ValidSeatCategories returns all valid seat categories
+var ValidSeatCategories = []SeatCategory{
+	SeatCategoryStandard,
+	SeatCategoryPremium,
+	SeatCategoryRecliner,
+}
+
+// IsValidSeatCategory checks whether the given category is supported
+func IsValidSeatCategory(category string) bool {
+	for _, c := range ValidSeatCategories {
+		if string(c) == category {
+			return true
+		}
+	}
+	return false
+}
+
+// SeatEventType represents a seat status change pushed to seat map subscribers
+type SeatEventType string
+
+const (
+	SeatEventLocked    SeatEventType = "LOCKED"
+	SeatEventReleased  SeatEventType = "RELEASED"
+	SeatEventSold      SeatEventType = "SOLD"
+	SeatEventExpired   SeatEventType = "EXPIRED"
+	SeatEventBlocked   SeatEventType = "BLOCKED"
+	SeatEventUnblocked SeatEventType = "UNBLOCKED"
+	SeatEventReset     SeatEventType = "RESET" // Missed events cannot be replayed; reload the seat map
+)
+
+// SeatTransition names a change recorded in the seat_events audit history
+type SeatTransition string
+
+const (
+	SeatTransitionLock    SeatTransition = "LOCK"
+	SeatTransitionRenew   SeatTransition = "RENEW"
+	SeatTransitionRelease SeatTransition = "RELEASE"
+	SeatTransitionExpire  SeatTransition = "EXPIRE"
+	SeatTransitionSell    SeatTransition = "SELL"
+	SeatTransitionRefund  SeatTransition = "REFUND"
+	SeatTransitionBlock   SeatTransition = "BLOCK"
+	SeatTransitionUnblock SeatTransition = "UNBLOCK"
+)
+
+// SeatActorType says who caused a seat transition
+type SeatActorType string
+
+const (
+	SeatActorUser   SeatActorType = "USER"   // The customer acting on their own seat
+	SeatActorStaff  SeatActorType = "STAFF"  // Staff or admin acting on someone else's seat
+	SeatActorSystem SeatActorType = "SYSTEM" // Background jobs such as the lock sweeper
+)
+
+// SeatLockBackend selects where seat holds are kept
+type SeatLockBackend string
+
+const (
+	SeatLockBackendMySQL      SeatLockBackend = "mysql"      // Row locks on show_seats (multi-node safe)
+	SeatLockBackendOptimistic SeatLockBackend = "optimistic" // Versioned compare-and-set on show_seats, no open transaction
+	SeatLockBackendMemory     SeatLockBackend = "memory"     // Process memory (single node only)
+)
+
+// SeatMapCellType describes what occupies a grid column of a seat map row
+type SeatMapCellType string
+
+const (
+	SeatMapCellSeat  SeatMapCellType = "SEAT"
+	SeatMapCellGap   SeatMapCellType = "GAP"   // No seat in this row only
+	SeatMapCellAisle SeatMapCellType = "AISLE" // No seat in any row
+)
+
+// SeatMapLegendKind distinguishes the two kinds of seat map legend entries
+type SeatMapLegendKind string
+
+const (
+	SeatMapLegendCategory SeatMapLegendKind = "CATEGORY"
+	SeatMapLegendStatus   SeatMapLegendKind = "STATUS"
+)
+
+// ScreenPositionFront places the screen in front of the first seat map row
+const ScreenPositionFront = "FRONT"
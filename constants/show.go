@@ -0,0 +1,46 @@
+package constants
+
+// DateLayout is the format of calendar-date query parameters such as ?date=2026-10-20
+const DateLayout = "2006-01-02"
+
+// ShowFormat represents the projection format of a show
+type ShowFormat string
+
+const (
+	ShowFormatStandard ShowFormat = "STANDARD"
+	ShowFormatIMAX     ShowFormat = "IMAX"
+	ShowFormat3D       ShowFormat = "3D"
+	ShowFormat4DX      ShowFormat = "4DX"
+	ShowFormatDolby    ShowFormat = "DOLBY"
+)
+
+// ValidShowFormats returns all valid show formats
+var ValidShowFormats = []ShowFormat{
+	ShowFormatStandard,
+	ShowFormatIMAX,
+	ShowFormat3D,
+	ShowFormat4DX,
+	ShowFormatDolby,
+}
+
+// IsValidShowFormat checks whether the given format is supported
+func IsValidShowFormat(format string) bool {
+	for _, f := range ValidShowFormats {
+		if string(f) == format {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitingRoomState describes where a user stands in a show's waiting room
+type WaitingRoomState string
+
+const (
+	WaitingRoomStateOpen     WaitingRoomState = "OPEN"     // No waiting room; lock and book freely
+	WaitingRoomStateQueued   WaitingRoomState = "QUEUED"   // Waiting for an admission slot
+	WaitingRoomStateAdmitted WaitingRoomState = "ADMITTED" // Holds an admission token
+)
+
+// AdmissionTokenAudience is the audience of waiting room admission tokens, keeping them apart from login tokens
+const AdmissionTokenAudience = "waiting-room"
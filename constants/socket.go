@@ -0,0 +1,14 @@
+package constants
+
+// Seat WebSocket commands sent by clients
+const (
+	SocketCommandLock    = "lock"
+	SocketCommandRelease = "release"
+)
+
+// Seat WebSocket message types pushed to clients
+const (
+	SocketMessageSeat   = "seat"
+	SocketMessageResult = "result"
+	SocketMessageError  = "error"
+)
@@ -0,0 +1,27 @@
+package constants
+
+// UserRole represents the role of a user account
+type UserRole string
+
+const (
+	UserRoleCustomer UserRole = "CUSTOMER"
+	UserRoleStaff    UserRole = "STAFF"
+	UserRoleAdmin    UserRole = "ADMIN"
+)
+
+// ValidUserRoles returns all valid user roles
+var ValidUserRoles = []UserRole{
+	UserRoleCustomer,
+	UserRoleStaff,
+	UserRoleAdmin,
+}
+
+// IsStaffRole reports whether the role may act on other users' seats and bookings
+func IsStaffRole(role string) bool {
+	return role == string(UserRoleStaff) || role == string(UserRoleAdmin)
+}
+
+// IsAdminRole reports whether the role may change show settings
+func IsAdminRole(role string) bool {
+	return role == string(UserRoleAdmin)
+}
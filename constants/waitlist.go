@@ -0,0 +1,27 @@
+package constants
+
+// WaitlistStatus represents where a waitlist entry stands
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting WaitlistStatus = "WAITING" // In line for freed seats
+	WaitlistStatusOffered WaitlistStatus = "OFFERED" // Seats are held for the user until the offer expires
+	WaitlistStatusClaimed WaitlistStatus = "CLAIMED" // The user booked from the offer
+	WaitlistStatusExpired WaitlistStatus = "EXPIRED" // The offer lapsed or was declined, or sales closed first
+	WaitlistStatusLeft    WaitlistStatus = "LEFT"    // The user left the waitlist
+)
+
+// ActiveWaitlistStatuses are the statuses of entries still on a show's waitlist
+var ActiveWaitlistStatuses = []WaitlistStatus{
+	WaitlistStatusWaiting,
+	WaitlistStatusOffered,
+}
+
+// NotificationType identifies the kind of message sent to a user
+type NotificationType string
+
+const (
+	NotificationWaitlistOffer        NotificationType = "WAITLIST_OFFER"
+	NotificationWaitlistOfferExpired NotificationType = "WAITLIST_OFFER_EXPIRED"
+	NotificationWaitlistClosed       NotificationType = "WAITLIST_CLOSED"
+)
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"movie-booking/constants"
+)
+
+// SeatEvent describes a change in a seat's status, as pushed to seat map subscribers
+type SeatEvent struct {
+	ID         uint64     `json:"id"`
+	Type       string     `json:"type"` // LOCKED, RELEASED, SOLD, EXPIRED, or RESET when a resume is not possible
+	ShowID     uint       `json:"show_id"`
+	SeatID     uint       `json:"seat_id"`
+	SeatName   string     `json:"seat_name"`
+	HoldID     *uint      `json:"hold_id,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	OccurredAt time.Time  `json:"occurred_at"`
+}
+
+// Subscription receives the seat events of one show
+type Subscription struct {
+	ShowID uint
+	Events <-chan SeatEvent
+
+	events chan SeatEvent
+	hub    *Hub
+	once   sync.Once
+}
+
+// Close stops delivery and releases the subscription
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Hub is an in-process publish/subscribe hub for seat events, keyed by show.
+// It keeps a short per-show history so reconnecting clients can resume from their last event ID.
+// A show's history is dropped once it has no subscribers and no event for historyTTL.
+// A nil *Hub is valid and drops everything, so services work without one.
+type Hub struct {
+	mu          sync.Mutex
+	nextID      uint64
+	historySize int
+	historyTTL  time.Duration
+	bufferSize  int
+	history     map[uint][]SeatEvent
+	subscribers map[uint]map[*Subscription]struct{}
+	lastPrune   time.Time
+}
+
+// NewHub creates a hub that remembers historySize events per show for historyTTL after the show goes quiet,
+// and buffers bufferSize events per subscriber
+func NewHub(historySize int, historyTTL time.Duration, bufferSize int) *Hub {
+	return &Hub{
+		historySize: historySize,
+		historyTTL:  historyTTL,
+		bufferSize:  bufferSize,
+		history:     make(map[uint][]SeatEvent),
+		subscribers: make(map[uint]map[*Subscription]struct{}),
+		lastPrune:   time.Now(),
+	}
+}
+
+// Publish assigns the event an ID and delivers it to every subscriber of its show.
+// Subscribers whose buffer is full are disconnected; they can resume from history.
+func (h *Hub) Publish(event SeatEvent) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	event.ID = h.nextID
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	history := append(h.history[event.ShowID], event)
+	if len(history) > h.historySize {
+		history = history[len(history)-h.historySize:]
+	}
+	h.history[event.ShowID] = history
+
+	for sub := range h.subscribers[event.ShowID] {
+		select {
+		case sub.events <- event:
+		default:
+			h.removeLocked(sub)
+		}
+	}
+
+	h.pruneLocked(event.OccurredAt)
+}
+
+// pruneLocked drops the history of shows nobody is watching whose last event is older than historyTTL.
+// It scans at most once per historyTTL; h.mu must be held.
+func (h *Hub) pruneLocked(now time.Time) {
+	if now.Sub(h.lastPrune) < h.historyTTL {
+		return
+	}
+	h.lastPrune = now
+
+	for showID, history := range h.history {
+		if len(h.subscribers[showID]) > 0 {
+			continue
+		}
+		if last := history[len(history)-1]; now.Sub(last.OccurredAt) >= h.historyTTL {
+			delete(h.history, showID)
+		}
+	}
+}
+
+// Subscribe registers for a show's events and returns the buffered events newer than lastEventID.
+// Event IDs are only meaningful to this process and older events are trimmed, so when lastEventID is not in the
+// show's history, for instance after a restart or from another replica, a single RESET event is returned instead
+// and the client reloads the seat map.
+func (h *Hub) Subscribe(showID uint, lastEventID uint64) (*Subscription, []SeatEvent) {
+	ch := make(chan SeatEvent, h.bufferSize)
+	sub := &Subscription{ShowID: showID, Events: ch, events: ch, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[showID] == nil {
+		h.subscribers[showID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[showID][sub] = struct{}{}
+
+	if lastEventID == 0 {
+		return sub, nil
+	}
+
+	history := h.history[showID]
+	for i, event := range history {
+		if event.ID == lastEventID {
+			return sub, append([]SeatEvent(nil), history[i+1:]...)
+		}
+	}
+	return sub, []SeatEvent{{
+		ID:         h.nextID,
+		Type:       string(constants.SeatEventReset),
+		ShowID:     showID,
+		OccurredAt: time.Now(),
+	}}
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(sub)
+}
+
+// removeLocked drops a subscriber and closes its channel; h.mu must be held
+func (h *Hub) removeLocked(sub *Subscription) {
+	sub.once.Do(func() {
+		delete(h.subscribers[sub.ShowID], sub)
+		if len(h.subscribers[sub.ShowID]) == 0 {
+			delete(h.subscribers, sub.ShowID)
+		}
+		close(sub.events)
+	})
+}
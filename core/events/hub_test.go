@@ -0,0 +1,55 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"movie-booking/constants"
+)
+
+func TestHubSubscribeReplaysOrResets(t *testing.T) {
+	hub := NewHub(2, time.Minute, 8)
+	for seatID := uint(1); seatID <= 3; seatID++ {
+		hub.Publish(SeatEvent{Type: string(constants.SeatEventLocked), ShowID: 1, SeatID: seatID})
+	}
+	// Event 4 belongs to another show; history keeps events 2 and 3 of show 1
+	hub.Publish(SeatEvent{Type: string(constants.SeatEventLocked), ShowID: 2, SeatID: 9})
+
+	tests := []struct {
+		name        string
+		lastEventID uint64
+		wantIDs     []uint64
+		wantReset   bool
+	}{
+		{name: "fresh subscriber", lastEventID: 0},
+		{name: "resume from kept event", lastEventID: 2, wantIDs: []uint64{3}},
+		{name: "up to date", lastEventID: 3},
+		{name: "trimmed from history", lastEventID: 1, wantReset: true},
+		{name: "event of another show", lastEventID: 4, wantReset: true},
+		{name: "unknown to this process", lastEventID: 99, wantReset: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay := hub.Subscribe(1, tt.lastEventID)
+			defer sub.Close()
+
+			if tt.wantReset {
+				if len(replay) != 1 || replay[0].Type != string(constants.SeatEventReset) || replay[0].ShowID != 1 {
+					t.Fatalf("want a single reset event, got %+v", replay)
+				}
+				if replay[0].ID != 4 {
+					t.Fatalf("reset event has ID %d, want the latest ID 4", replay[0].ID)
+				}
+				return
+			}
+			if len(replay) != len(tt.wantIDs) {
+				t.Fatalf("replayed %+v, want IDs %v", replay, tt.wantIDs)
+			}
+			for i, event := range replay {
+				if event.ID != tt.wantIDs[i] {
+					t.Fatalf("replayed %+v, want IDs %v", replay, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate mockgen -destination=../../datastore/fake/fake.go -package=fake movie-booking/core/model DataStore
@@ -13,7 +14,11 @@ type DataStore interface {
 	MovieStore
 	ShowStore
 	ShowSeatStore
+	SeatHoldStore
 	BookingStore
+	WaitlistStore
+	WaitingRoomStore
+	AdvisoryLockStore
 
 	// Transaction support
 	Begin(ctx context.Context) (DataStore, error)
@@ -28,6 +33,7 @@ type UserStore interface {
 	CreateUser(ctx context.Context, user *User) (*User, error)
 	GetUserByID(ctx context.Context, id uint) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	GetUserByIDForUpdate(ctx context.Context, id uint) (*User, error) // FOR UPDATE lock, serializes a user's seat locks
 }
 
 // MovieStore handles movie operations
@@ -38,21 +44,78 @@ type MovieStore interface {
 
 // ShowStore handles show operations
 type ShowStore interface {
-	GetShowsByMovieID(ctx context.Context, movieID uint) ([]Show, error)
+	GetShowsByMovieID(ctx context.Context, movieID uint, filter ShowFilter) ([]Show, error)
 	GetShowByID(ctx context.Context, id uint) (*Show, error)
+	GetShowByIDForUpdate(ctx context.Context, id uint) (*Show, error) // FOR UPDATE lock, serializes a show's waiting room
+	SetShowWaitingRoom(ctx context.Context, id uint, enabled bool) error
 }
 
 // ShowSeatStore handles seat operations
 type ShowSeatStore interface {
 	GetSeatsByShowID(ctx context.Context, showID uint) ([]ShowSeat, error)
+	GetSeatByID(ctx context.Context, id uint) (*ShowSeat, error)
 	GetSeatByIDForUpdate(ctx context.Context, id uint) (*ShowSeat, error) // FOR UPDATE lock
-	UpdateSeat(ctx context.Context, id uint, updates map[string]interface{}) error
+	// Seat writes append event (when non-nil) to seat_events atomically with the update
+	UpdateSeat(ctx context.Context, id uint, updates map[string]interface{}, event *SeatEvent) error
+	UpdateSeatIfVersion(ctx context.Context, id uint, version uint, updates map[string]interface{}, event *SeatEvent) (bool, error)
+	LockSeatIfAvailable(ctx context.Context, id uint, version uint, lockedBefore time.Time, updates map[string]interface{}, event *SeatEvent) (bool, error)
+	GetSeatEventsBySeatID(ctx context.Context, seatID uint) ([]SeatEvent, error)
+	GetSeatVersionByShowID(ctx context.Context, showID uint, lockedBefore time.Time) (uint64, error)
+	GetUpcomingSeatsByScreenForUpdate(ctx context.Context, screenID uint, seatNames []string, startAfter time.Time) ([]ShowSeat, error) // FOR UPDATE lock
 	CreateSeat(ctx context.Context, seat *ShowSeat) (*ShowSeat, error)
+	GetExpiredSeatLocksForUpdate(ctx context.Context, lockedBefore time.Time, limit int) ([]ShowSeat, error) // FOR UPDATE SKIP LOCKED
+	GetLockedSeatsByUser(ctx context.Context, userID uint, lockedAfter time.Time) ([]ShowSeat, error)
+}
+
+// SeatHoldStore handles seat hold operations
+type SeatHoldStore interface {
+	CreateSeatHold(ctx context.Context, hold *SeatHold) (*SeatHold, error)
+	GetSeatHoldByID(ctx context.Context, id uint) (*SeatHold, error)
+	GetSeatHoldByIDForUpdate(ctx context.Context, id uint) (*SeatHold, error) // FOR UPDATE lock
+	UpdateSeatHold(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeleteSeatHold(ctx context.Context, id uint) error // Seats still pointing at the hold have hold_id cleared
 }
 
 // BookingStore handles booking operations
 type BookingStore interface {
-	CreateBooking(ctx context.Context, booking *Booking) (*Booking, error)
-	GetBookingByUserAndIdempotencyKey(ctx context.Context, userID uint, idempotencyKey string) (*Booking, error)
+	CreateOrder(ctx context.Context, order *Order) (*Order, error) // Creates the order with its line items
+	GetOrderByUserAndIdempotencyKey(ctx context.Context, userID uint, idempotencyKey string) (*Order, error) // nil if none
+	UpdateOrder(ctx context.Context, id uint, updates map[string]interface{}) error
+	GetOrderByIDForUpdate(ctx context.Context, id uint) (*Order, error)               // FOR UPDATE lock
+	GetOrderByBookingIDForUpdate(ctx context.Context, bookingID uint) (*Order, error) // FOR UPDATE lock on the order only
+	GetExpiredPendingOrdersForUpdate(ctx context.Context, createdBefore time.Time, limit int) ([]Order, error) // FOR UPDATE SKIP LOCKED
+	GetBookingsByOrderIDForUpdate(ctx context.Context, orderID uint) ([]Booking, error) // FOR UPDATE lock, seats preloaded
 	GetBookingByID(ctx context.Context, id uint) (*Booking, error)
+	GetBookingByIDForUpdate(ctx context.Context, id uint) (*Booking, error) // FOR UPDATE lock
+	// Booking writes append event (when non-nil) to booking_events atomically with the update
+	UpdateBooking(ctx context.Context, id uint, updates map[string]interface{}, event *BookingEvent) error
+	CreateBookingEvents(ctx context.Context, events []BookingEvent) error
+	CountBookingsByUserAndShow(ctx context.Context, userID, showID uint) (int64, error) // Inactive statuses excluded
+	GetBookingsByUser(ctx context.Context, filter BookingFilter) ([]Booking, error)
+}
+
+// WaitlistStore handles waitlist operations
+type WaitlistStore interface {
+	CreateWaitlistEntry(ctx context.Context, entry *WaitlistEntry) (*WaitlistEntry, error)
+	GetLatestWaitlistEntry(ctx context.Context, showID, userID uint) (*WaitlistEntry, error) // nil if never on the waitlist
+	GetActiveWaitlistEntriesByShow(ctx context.Context, showID uint) ([]WaitlistEntry, error)
+	GetActiveWaitlistShowIDs(ctx context.Context) ([]uint, error)
+	UpdateWaitlistEntryIfStatus(ctx context.Context, id uint, status string, updates map[string]interface{}) (bool, error)
+	ClaimWaitlistOffer(ctx context.Context, holdID uint) error
+}
+
+// WaitingRoomStore handles waiting room operations
+type WaitingRoomStore interface {
+	CreateWaitingRoomEntry(ctx context.Context, entry *WaitingRoomEntry) (*WaitingRoomEntry, error)
+	GetWaitingRoomEntry(ctx context.Context, showID, userID uint) (*WaitingRoomEntry, error) // nil if not in the room
+	GetWaitingRoomEntriesByShow(ctx context.Context, showID uint) ([]WaitingRoomEntry, error)
+	UpdateWaitingRoomEntry(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeleteWaitingRoomEntries(ctx context.Context, ids []uint) error
+	DeleteWaitingRoomEntriesByShow(ctx context.Context, showID uint) error
+}
+
+// AdvisoryLockStore handles named cross-process locks
+type AdvisoryLockStore interface {
+	// TryAdvisoryLock takes the named lock without waiting; release must be called once acquired is true
+	TryAdvisoryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
 }
@@ -1,7 +1,10 @@
 package model
 
 import (
+	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Movie represents a movie entity
@@ -24,6 +27,11 @@ type Theatre struct {
 	ID       uint   `gorm:"primaryKey" json:"id"`
 	Name     string `gorm:"type:varchar(255);not null" json:"name"`
 	Location string `gorm:"type:varchar(255)" json:"location"`
+	TimeZone string `gorm:"type:varchar(64);not null;default:'UTC'" json:"time_zone"` // IANA name, e.g. Asia/Kolkata
+	PreventSingleSeatGaps bool `gorm:"not null;default:true" json:"prevent_single_seat_gaps"` // Reject selections that strand one empty seat
+	FullRefundHours       int  `gorm:"not null;default:24" json:"full_refund_hours"`         // Cancel at least this long before the show for a full refund
+	PartialRefundHours    int  `gorm:"not null;default:2" json:"partial_refund_hours"`       // Cancel at least this long before for a partial refund; later is not allowed
+	PartialRefundPercent  int  `gorm:"not null;default:50" json:"partial_refund_percent"`
 	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
@@ -32,30 +40,111 @@ func (Theatre) TableName() string {
 	return "theatres"
 }
 
+// BeforeSave rejects a time zone that cannot be loaded; show times would otherwise be shown in UTC
+func (t *Theatre) BeforeSave(tx *gorm.DB) error {
+	if t.TimeZone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(t.TimeZone); err != nil {
+		return fmt.Errorf("invalid theatre time zone %q: %w", t.TimeZone, err)
+	}
+	return nil
+}
+
+// Screen represents an auditorium inside a theatre
+type Screen struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TheatreID uint      `gorm:"not null;index" json:"theatre_id"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	Layout    string    `gorm:"type:varchar(50);not null;default:'STANDARD'" json:"layout"` // Selects the best-available seat scorer
+	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Screen) TableName() string {
+	return "screens"
+}
+
 // Show represents a movie show at a theatre
 type Show struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	MovieID   uint      `gorm:"not null;index" json:"movie_id"`
 	TheatreID uint      `gorm:"not null;index" json:"theatre_id"`
+	ScreenID  *uint     `gorm:"index" json:"screen_id,omitempty"`
 	StartTime time.Time `gorm:"type:timestamp;not null" json:"start_time"`
+	Format           string  `gorm:"type:varchar(20);not null;default:'STANDARD';index" json:"format"` // STANDARD, IMAX, 3D, 4DX, DOLBY
+	AudioLanguage    string  `gorm:"type:varchar(50);not null;default:'en'" json:"audio_language"`
+	SubtitleLanguage *string `gorm:"type:varchar(50)" json:"subtitle_language,omitempty"`
+	BasePrice        float64 `gorm:"type:decimal(10,2);not null;default:0" json:"base_price"`
+	SalesOpenAt      *time.Time `gorm:"type:timestamp NULL" json:"sales_open_at,omitempty"`  // NULL = on sale immediately
+	SalesCloseAt     *time.Time `gorm:"type:timestamp NULL" json:"sales_close_at,omitempty"` // NULL = start_time minus configured cutoff
+	WaitingRoomEnabled bool     `gorm:"not null;default:false" json:"waiting_room_enabled"` // Lock and book only with an admission token
+	LocalStartTime   string     `gorm:"-" json:"local_start_time,omitempty"` // start_time in the theatre's time zone
+	UTCOffset        string     `gorm:"-" json:"utc_offset,omitempty"`       // e.g. +05:30
 	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	
 	// Relations
-	Movie   Movie   `gorm:"foreignKey:MovieID" json:"movie,omitempty"`
-	Theatre Theatre `gorm:"foreignKey:TheatreID" json:"theatre,omitempty"`
+	Movie           Movie            `gorm:"foreignKey:MovieID" json:"movie,omitempty"`
+	Theatre         Theatre          `gorm:"foreignKey:TheatreID" json:"theatre,omitempty"`
+	Screen          *Screen          `gorm:"foreignKey:ScreenID" json:"screen,omitempty"`
+	FormatSurcharge *FormatSurcharge `gorm:"foreignKey:Format;references:Format" json:"format_surcharge,omitempty"`
 }
 
 func (Show) TableName() string {
 	return "shows"
 }
 
+// TicketPrice returns the base price plus the surcharge for the show's format
+func (s *Show) TicketPrice() float64 {
+	if s.FormatSurcharge == nil {
+		return s.BasePrice
+	}
+	return s.BasePrice + s.FormatSurcharge.Surcharge
+}
+
+// FormatSurcharge holds the extra charge applied to every ticket of a premium format
+type FormatSurcharge struct {
+	Format    string    `gorm:"type:varchar(20);primaryKey" json:"format"`
+	Surcharge float64   `gorm:"type:decimal(10,2);not null;default:0" json:"surcharge"`
+	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"-"`
+	UpdatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"-"`
+}
+
+func (FormatSurcharge) TableName() string {
+	return "format_surcharges"
+}
+
+// ShowFilter narrows down the shows returned for a movie
+type ShowFilter struct {
+	Format           string
+	AudioLanguage    string
+	SubtitleLanguage string
+	Date             *time.Time // Calendar day (UTC midnight) matched against the theatre's local date
+}
+
+// BookingFilter selects a page of a user's bookings, ordered by show start time then booking ID
+type BookingFilter struct {
+	UserID uint
+	Period string         // upcoming (ascending), past (descending) or empty for all (descending)
+	Now    time.Time      // Divides upcoming from past
+	After  *BookingCursor // Position of the last booking on the previous page
+	Limit  int
+}
+
+// BookingCursor is a position in a booking listing
+type BookingCursor struct {
+	StartTime time.Time
+	ID        uint
+}
+
 // User represents a user entity
 type User struct {
 	ID           uint   `gorm:"primaryKey" json:"id"`
 	Email        string `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
 	PasswordHash string `gorm:"type:varchar(255);not null" json:"-"`
 	Name         string `gorm:"type:varchar(255)" json:"name"`
+	Role         string `gorm:"type:varchar(20);not null;default:'CUSTOMER'" json:"role"` // CUSTOMER, STAFF, ADMIN
 	CreatedAt    time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt    time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
@@ -69,9 +158,15 @@ type ShowSeat struct {
 	ID       uint       `gorm:"primaryKey" json:"id"`
 	ShowID   uint       `gorm:"not null;index" json:"show_id"`
 	SeatName string     `gorm:"type:varchar(10);not null" json:"seat_name"`
-	Status   string     `gorm:"type:varchar(50);default:'AVAILABLE';index" json:"status"` // AVAILABLE, LOCKED, SOLD
+	RowLabel   string   `gorm:"type:varchar(5);not null" json:"row_label"`       // e.g. "A", nearest the screen first
+	SeatNumber int      `gorm:"not null" json:"seat_number"`                     // Position within the row
+	Category   string   `gorm:"type:varchar(20);not null;default:'STANDARD'" json:"category"` // STANDARD, PREMIUM, RECLINER
+	Status   string     `gorm:"type:varchar(50);default:'AVAILABLE';index" json:"status"` // AVAILABLE, LOCKED, SOLD, BLOCKED
+	BlockReason *string  `gorm:"type:varchar(255)" json:"block_reason,omitempty"` // Why a BLOCKED seat is out of sale
 	LockedAt *time.Time  `gorm:"type:timestamp NULL" json:"locked_at,omitempty"`
 	UserID   *uint       `gorm:"index" json:"user_id,omitempty"` // WHO locked this seat
+	HoldID   *uint       `gorm:"index" json:"hold_id,omitempty"` // Hold this lock belongs to
+	Version  uint        `gorm:"not null;default:0" json:"-"`    // Bumped on every update, for compare-and-set locking
 	CreatedAt time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	
@@ -84,22 +179,138 @@ func (ShowSeat) TableName() string {
 	return "show_seats"
 }
 
-// Booking represents a confirmed booking
+// SeatHold groups the seats locked together by one lock request
+type SeatHold struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ShowID    uint      `gorm:"not null;index" json:"show_id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	Extensions int      `gorm:"not null;default:0" json:"extensions"` // Number of times the hold was renewed
+	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
+
+	// Relations
+	Seats []ShowSeat `gorm:"foreignKey:HoldID" json:"seats,omitempty"`
+}
+
+func (SeatHold) TableName() string {
+	return "seat_holds"
+}
+
+// SeatEvent is an append-only record of one seat transition, written with the seat update it describes
+type SeatEvent struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ShowSeatID  uint      `gorm:"not null;index" json:"show_seat_id"`
+	ShowID      uint      `gorm:"not null;index" json:"show_id"`
+	Transition  string    `gorm:"type:varchar(20);not null" json:"transition"` // LOCK, RENEW, RELEASE, EXPIRE, SELL, REFUND, BLOCK, UNBLOCK
+	FromStatus  string    `gorm:"type:varchar(50);not null" json:"from_status"`
+	ToStatus    string    `gorm:"type:varchar(50);not null" json:"to_status"`
+	PrevUserID  *uint     `json:"prev_user_id,omitempty"` // Holder before the transition
+	PrevHoldID  *uint     `json:"prev_hold_id,omitempty"`
+	UserID      *uint     `json:"user_id,omitempty"` // Holder after the transition
+	HoldID      *uint     `json:"hold_id,omitempty"`
+	BookingID   *uint     `json:"booking_id,omitempty"`
+	ActorType   string    `gorm:"type:varchar(20);not null" json:"actor_type"` // USER, STAFF, SYSTEM
+	ActorUserID *uint     `json:"actor_user_id,omitempty"`
+	Reason      string    `gorm:"type:varchar(255);not null;default:''" json:"reason"`
+	CreatedAt   time.Time `gorm:"type:timestamp(3);default:CURRENT_TIMESTAMP(3)" json:"created_at"`
+}
+
+func (SeatEvent) TableName() string {
+	return "seat_events"
+}
+
+// Order is one purchase of seats for a show, with a booking line item per seat
+type Order struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	UserID         uint      `gorm:"not null;index" json:"user_id"`
+	ShowID         uint      `gorm:"not null;index" json:"show_id"`
+	Status         string    `gorm:"type:varchar(20);not null;default:'CONFIRMED'" json:"status"` // Follows its bookings, see syncOrderStatus
+	TotalAmount    float64   `gorm:"type:decimal(10,2);not null;default:0" json:"total_amount"`
+	IdempotencyKey string    `gorm:"type:varchar(255);index" json:"-"` // For idempotency
+	CreatedAt      time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
+
+	// Relations
+	Bookings []Booking `gorm:"foreignKey:OrderID" json:"bookings,omitempty"`
+}
+
+func (Order) TableName() string {
+	return "orders"
+}
+
+// Booking is the line item of an order for one seat
 type Booking struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
+	OrderID   uint      `gorm:"not null;index" json:"order_id"`
 	UserID    uint      `gorm:"not null;index" json:"user_id"`
 	ShowID    uint      `gorm:"not null;index" json:"show_id"`
 	SeatID    uint      `gorm:"not null;index" json:"seat_id"`
-	IdempotencyKey string `gorm:"type:varchar(255);index" json:"-"` // For idempotency
+	Status    string    `gorm:"type:varchar(20);not null;default:'CONFIRMED'" json:"status"` // PENDING_PAYMENT, CONFIRMED, CANCELLED, REFUNDED, CHECKED_IN, EXPIRED
+	Amount    float64   `gorm:"type:decimal(10,2);not null;default:0" json:"amount"`
+	BasePrice       float64 `gorm:"type:decimal(10,2);not null;default:0" json:"base_price"`       // Show base price when booked
+	FormatSurcharge float64 `gorm:"type:decimal(10,2);not null;default:0" json:"format_surcharge"` // Format surcharge when booked
+	RefundAmount *float64   `gorm:"type:decimal(10,2)" json:"refund_amount,omitempty"` // Owed to the user once cancelled
+	CancelledAt  *time.Time `gorm:"type:timestamp NULL" json:"cancelled_at,omitempty"`
 	CreatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	
 	// Relations
-	User User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Show Show     `gorm:"foreignKey:ShowID" json:"show,omitempty"`
-	Seat ShowSeat `gorm:"foreignKey:SeatID" json:"seat,omitempty"`
+	User   User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Show   Show           `gorm:"foreignKey:ShowID" json:"show,omitempty"`
+	Seat   ShowSeat       `gorm:"foreignKey:SeatID" json:"seat,omitempty"`
+	Events []BookingEvent `gorm:"foreignKey:BookingID" json:"events,omitempty"` // Status history, oldest first
 }
 
 func (Booking) TableName() string {
 	return "bookings"
 }
+
+// BookingEvent records one status transition of a booking
+type BookingEvent struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	BookingID   uint      `gorm:"not null;index" json:"booking_id"`
+	FromStatus  string    `gorm:"type:varchar(20);not null;default:''" json:"from_status"` // Empty when the booking was created
+	ToStatus    string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	ActorType   string    `gorm:"type:varchar(20);not null" json:"actor_type"` // USER, STAFF, SYSTEM
+	ActorUserID *uint     `json:"actor_user_id,omitempty"`
+	Reason      string    `gorm:"type:varchar(255);not null;default:''" json:"reason"`
+	CreatedAt   time.Time `gorm:"type:timestamp(3);default:CURRENT_TIMESTAMP(3)" json:"created_at"`
+}
+
+func (BookingEvent) TableName() string {
+	return "booking_events"
+}
+
+// WaitingRoomEntry is a user queued for, or admitted to, a show's waiting room; the queue runs in ID order
+type WaitingRoomEntry struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	ShowID        uint       `gorm:"not null;uniqueIndex:uniq_show_user" json:"show_id"`
+	UserID        uint       `gorm:"not null;uniqueIndex:uniq_show_user;index" json:"user_id"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'QUEUED'" json:"status"` // QUEUED, ADMITTED
+	LastSeenAt    time.Time  `gorm:"type:timestamp;not null" json:"last_seen_at"`              // Last poll while queued
+	AdmittedUntil *time.Time `gorm:"type:timestamp NULL" json:"admitted_until,omitempty"`
+	CreatedAt     time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (WaitingRoomEntry) TableName() string {
+	return "waiting_room_entries"
+}
+
+// WaitlistEntry is a user waiting for seats of a sold-out show; freed seats are offered to entries in ID order
+type WaitlistEntry struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	ShowID         uint       `gorm:"not null;index" json:"show_id"`
+	UserID         uint       `gorm:"not null;index" json:"user_id"`
+	SeatCount      int        `gorm:"not null" json:"seat_count"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'WAITING'" json:"status"` // WAITING, OFFERED, CLAIMED, EXPIRED, LEFT
+	HoldID         *uint      `gorm:"index" json:"hold_id,omitempty"`                           // Hold of the current or last offer
+	OfferedAt      *time.Time `gorm:"type:timestamp NULL" json:"offered_at,omitempty"`
+	OfferExpiresAt *time.Time `gorm:"type:timestamp NULL" json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
@@ -36,7 +36,7 @@ func (s *authService) Login(ctx context.Context, email, password string) (*types
 	}
 
 	// Generate JWT token
-	token, err := s.generateJWT(user.ID, user.Email)
+	token, err := s.generateJWT(user.ID, user.Email, user.Role)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -47,16 +47,18 @@ func (s *authService) Login(ctx context.Context, email, password string) (*types
 			ID:    user.ID,
 			Name:  user.Name,
 			Email: user.Email,
+			Role:  user.Role,
 		},
 	}, nil
 }
 
 // generateJWT creates a JWT token with RS256 (simplified to HS256 for MVP, but structure supports RS256)
-func (s *authService) generateJWT(userID uint, email string) (string, error) {
+func (s *authService) generateJWT(userID uint, email string, role string) (string, error) {
 	expiry := config.GetJWTExpiry()
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
+		"role":    role,
 		"exp":     time.Now().Add(expiry).Unix(),
 		"iat":     time.Now().Unix(),
 	}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	"movie-booking/util/errors"
+)
+
+// CancelBooking cancels a confirmed or unpaid booking under its theatre's refund policy, putting the seat back on sale
+// and recording the refund owed in the same transaction
+func (s *bookingService) CancelBooking(ctx context.Context, bookingID uint, userID uint, isStaff bool) (*types.CancelBookingResponse, error) {
+	// Begin transaction
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback on panic or error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback(ctx)
+			panic(r)
+		}
+	}()
+
+	// Step 1: Lock the order, then the booking row using FOR UPDATE; other users' bookings look missing
+	order, err := tx.GetOrderByBookingIDForUpdate(ctx, bookingID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.NewHTTPError(http.StatusNotFound, "booking not found")
+	}
+
+	booking, err := tx.GetBookingByIDForUpdate(ctx, bookingID)
+	if err != nil || (booking.UserID != userID && !isStaff) {
+		tx.Rollback(ctx)
+		return nil, errors.NewHTTPError(http.StatusNotFound, "booking not found")
+	}
+
+	// Step 2: Validate the status, then the theatre's refund policy
+	if err := checkBookingTransition(booking.Status, constants.BookingStatusCancelled); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// An unpaid booking has nothing to refund and may be dropped until its payment expires
+	now := time.Now()
+	percent, refund := 0, 0.0
+	seatTransition := constants.SeatTransitionRelease
+	if booking.Status != string(constants.BookingStatusPendingPayment) {
+		show, err := tx.GetShowByID(ctx, booking.ShowID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("failed to get show: %w", err)
+		}
+
+		percent, err = refundPercent(&show.Theatre, show.StartTime, now)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		refund = math.Round(booking.Amount*float64(percent)) / 100
+		seatTransition = constants.SeatTransitionRefund
+	}
+
+	// Step 3: Cancel the booking
+	actor := seatActor{userID: userID, isStaff: isStaff}
+	if err := transitionBooking(ctx, tx, booking, constants.BookingStatusCancelled, map[string]interface{}{
+		"refund_amount": refund,
+		"cancelled_at":  now,
+	}, actor, "booking cancelled"); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// The order follows its bookings, so it is cancelled with its last live line item
+	if err := syncOrderStatus(ctx, tx, order); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// Step 4: Put the seat back on sale, recording the refund, if any, in the seat history
+	seat, err := releaseBookedSeat(ctx, tx, booking, seatTransition, actor, "booking cancelled")
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// Step 5: Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	publishSeatEvents(s.events, constants.SeatEventReleased, []model.ShowSeat{*seat}, nil, nil)
+	notifySeatsFreed(s.waitlist, []model.ShowSeat{*seat})
+
+	return &types.CancelBookingResponse{
+		BookingID:     booking.ID,
+		OrderID:       booking.OrderID,
+		Status:        booking.Status,
+		Amount:        booking.Amount,
+		RefundAmount:  refund,
+		RefundPercent: percent,
+		CancelledAt:   now,
+		Message:       fmt.Sprintf("Booking cancelled, %.2f will be refunded.", refund),
+	}, nil
+}
+
+// releaseBookedSeat puts the seat of a booking leaving the seat quota back on sale, recording the booking in the
+// seat history
+func releaseBookedSeat(ctx context.Context, tx model.DataStore, booking *model.Booking, transition constants.SeatTransition,
+	actor seatActor, reason string) (*model.ShowSeat, error) {
+	seat, err := tx.GetSeatByIDForUpdate(ctx, booking.SeatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat: %w", err)
+	}
+
+	updates := releasedSeatUpdates()
+	event := newSeatEvent(seat, transition, updates, actor, reason)
+	event.BookingID = &booking.ID
+
+	if err := tx.UpdateSeat(ctx, seat.ID, updates, event); err != nil {
+		return nil, fmt.Errorf("failed to update seat: %w", err)
+	}
+	return seat, nil
+}
+
+// refundPercent is the share of the price refunded for cancelling at now: all of it up to the theatre's full refund
+// cutoff, the partial percentage up to its partial cutoff, and no cancellation after that
+func refundPercent(theatre *model.Theatre, startTime, now time.Time) (int, error) {
+	untilStart := startTime.Sub(now)
+	switch {
+	case untilStart >= time.Duration(theatre.FullRefundHours)*time.Hour:
+		return 100, nil
+	case untilStart >= time.Duration(theatre.PartialRefundHours)*time.Hour:
+		return theatre.PartialRefundPercent, nil
+	}
+	return 0, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeCancellationClosed,
+		fmt.Sprintf("bookings can only be cancelled until %d hours before the show", theatre.PartialRefundHours))
+}
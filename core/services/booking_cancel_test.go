@@ -0,0 +1,55 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/model"
+)
+
+func TestRefundPercent(t *testing.T) {
+	theatre := &model.Theatre{FullRefundHours: 24, PartialRefundHours: 2, PartialRefundPercent: 50}
+	start := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		untilStart  time.Duration
+		wantPercent int
+		wantClosed  bool
+	}{
+		{name: "days before", untilStart: 72 * time.Hour, wantPercent: 100},
+		{name: "at the full refund cutoff", untilStart: 24 * time.Hour, wantPercent: 100},
+		{name: "just after the full refund cutoff", untilStart: 24*time.Hour - time.Second, wantPercent: 50},
+		{name: "at the partial refund cutoff", untilStart: 2 * time.Hour, wantPercent: 50},
+		{name: "just after the partial refund cutoff", untilStart: 2*time.Hour - time.Second, wantClosed: true},
+		{name: "after the show started", untilStart: -time.Hour, wantClosed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percent, err := refundPercent(theatre, start, start.Add(-tt.untilStart))
+			if tt.wantClosed {
+				expectErrorCode(t, err, constants.ErrCodeCancellationClosed)
+				return
+			}
+			if err != nil {
+				t.Fatalf("refundPercent: %v", err)
+			}
+			if percent != tt.wantPercent {
+				t.Fatalf("refund percent %d, want %d", percent, tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestRefundPercentWithoutPartialWindow(t *testing.T) {
+	// Equal cutoffs leave no partial refund window
+	theatre := &model.Theatre{FullRefundHours: 4, PartialRefundHours: 4, PartialRefundPercent: 50}
+	start := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+
+	if percent, err := refundPercent(theatre, start, start.Add(-4*time.Hour)); err != nil || percent != 100 {
+		t.Fatalf("at the cutoff got %d, %v; want a full refund", percent, err)
+	}
+	_, err := refundPercent(theatre, start, start.Add(-4*time.Hour+time.Second))
+	expectErrorCode(t, err, constants.ErrCodeCancellationClosed)
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/core/model"
+	"movie-booking/util/errors"
+)
+
+// ListBookings returns a page of the user's bookings, upcoming ones soonest first and past ones most recent first
+func (s *bookingService) ListBookings(ctx context.Context, userID uint, req *types.ListBookingsRequest) (*types.BookingListResponse, error) {
+	filter := model.BookingFilter{
+		UserID: userID,
+		Period: req.Period,
+		Now:    time.Now(),
+		Limit:  req.Limit + 1, // One extra row tells whether another page follows
+	}
+	if req.Cursor != "" {
+		cursor, err := decodeBookingCursor(req.Cursor)
+		if err != nil {
+			return nil, errors.NewHTTPError(http.StatusBadRequest, "invalid cursor")
+		}
+		filter.After = cursor
+	}
+
+	bookings, err := s.store.GetBookingsByUser(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.BookingListResponse{Bookings: make([]types.BookingSummary, 0, len(bookings))}
+	if len(bookings) > req.Limit {
+		bookings = bookings[:req.Limit]
+		last := bookings[len(bookings)-1]
+		res.NextCursor = encodeBookingCursor(model.BookingCursor{StartTime: last.Show.StartTime, ID: last.ID})
+	}
+	for i := range bookings {
+		res.Bookings = append(res.Bookings, bookingSummary(&bookings[i]))
+	}
+	return res, nil
+}
+
+// GetBooking returns one booking in full. Bookings of other users look missing unless the caller is staff
+func (s *bookingService) GetBooking(ctx context.Context, bookingID uint, userID uint, isStaff bool) (*types.BookingDetailResponse, error) {
+	booking, err := s.store.GetBookingByID(ctx, bookingID)
+	if err != nil || (booking.UserID != userID && !isStaff) {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "booking not found")
+	}
+
+	summary := bookingSummary(booking)
+	return &types.BookingDetailResponse{
+		BookingID: summary.BookingID,
+		OrderID:   summary.OrderID,
+		UserID:    booking.UserID,
+		Status:    summary.Status,
+		BookedAt:  summary.BookedAt,
+		Show:      summary.Show,
+		Movie:     summary.Movie,
+		Theatre:   summary.Theatre,
+		Seats:     []types.BookingSeat{summary.Seat},
+		Price:     bookingPrice(booking),
+
+		RefundAmount: booking.RefundAmount,
+		CancelledAt:  booking.CancelledAt,
+
+		History: bookingHistory(booking.Events),
+	}, nil
+}
+
+// bookingHistory lists a booking's recorded status transitions
+func bookingHistory(bookingEvents []model.BookingEvent) []types.BookingStatusChange {
+	history := make([]types.BookingStatusChange, 0, len(bookingEvents))
+	for _, event := range bookingEvents {
+		history = append(history, types.BookingStatusChange{
+			FromStatus: event.FromStatus,
+			Status:     event.ToStatus,
+			Reason:     event.Reason,
+			At:         event.CreatedAt,
+		})
+	}
+	return history
+}
+
+// bookingPrice reports the base price and format surcharge recorded when the booking was made, so later
+// price changes to the show or its format never alter it
+func bookingPrice(booking *model.Booking) types.BookingPrice {
+	return types.BookingPrice{
+		BasePrice:       booking.BasePrice,
+		FormatSurcharge: booking.FormatSurcharge,
+		Total:           booking.Amount,
+	}
+}
+
+// bookingSummary flattens a booking with its show, movie, theatre, screen and seat preloaded
+func bookingSummary(booking *model.Booking) types.BookingSummary {
+	show := &booking.Show
+	localizeShow(show)
+
+	summary := types.BookingSummary{
+		BookingID: booking.ID,
+		OrderID:   booking.OrderID,
+		Status:    booking.Status,
+		Amount:    booking.Amount,
+		BookedAt:  booking.CreatedAt,
+		Show: types.BookingShow{
+			ID:               show.ID,
+			StartTime:        show.StartTime,
+			LocalStartTime:   show.LocalStartTime,
+			Format:           show.Format,
+			AudioLanguage:    show.AudioLanguage,
+			SubtitleLanguage: show.SubtitleLanguage,
+		},
+		Movie: types.BookingMovie{
+			ID:           show.Movie.ID,
+			Title:        show.Movie.Title,
+			DurationMins: show.Movie.DurationMins,
+			Rating:       show.Movie.ContentRating,
+		},
+		Theatre: types.BookingTheatre{
+			ID:       show.Theatre.ID,
+			Name:     show.Theatre.Name,
+			Location: show.Theatre.Location,
+		},
+		Seat: types.BookingSeat{
+			ID:       booking.Seat.ID,
+			Name:     booking.Seat.SeatName,
+			Row:      booking.Seat.RowLabel,
+			Number:   booking.Seat.SeatNumber,
+			Category: booking.Seat.Category,
+		},
+	}
+	if show.Screen != nil {
+		summary.Show.Screen = show.Screen.Name
+	}
+	return summary
+}
+
+// encodeBookingCursor makes an opaque cursor from the position of a page's last booking
+func encodeBookingCursor(cursor model.BookingCursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.StartTime.Unix(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeBookingCursor reverses encodeBookingCursor
+func decodeBookingCursor(encoded string) (*model.BookingCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	startTime, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &model.BookingCursor{StartTime: time.Unix(startTime, 0).UTC(), ID: uint(id)}, nil
+}
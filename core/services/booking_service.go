@@ -3,39 +3,42 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"time"
 
 	"movie-booking/api/v1/types"
 	"movie-booking/config"
 	"movie-booking/constants"
+	"movie-booking/core/events"
 	"movie-booking/core/model"
 	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
 )
 
 type bookingService struct {
-	store model.DataStore
+	store    model.DataStore
+	events   *events.Hub
+	locks    coretypes.SeatLockManager
+	waitlist coretypes.SeatAvailabilityListener
 }
 
 // NewBookingService creates a new booking service
 func NewBookingService(clients *coretypes.Clients, store model.DataStore) BookingServiceInterface {
-	return &bookingService{store: store}
+	return &bookingService{store: store, events: clients.SeatEvents, locks: seatLockManager(clients), waitlist: clients.Waitlist}
 }
 
-// CreateBooking converts a locked seat into a confirmed booking
+// CreateBooking converts held seats into a confirmed or, with a payment timeout, unpaid order with a booking line item per seat
 func (s *bookingService) CreateBooking(ctx context.Context, input *types.CreateBookingInput) (*types.BookingResponse, error) {
 	// Check idempotency if key provided
 	if input.IdempotencyKey != "" {
-		existing, err := s.store.GetBookingByUserAndIdempotencyKey(ctx, input.UserID, input.IdempotencyKey)
+		existing, err := s.store.GetOrderByUserAndIdempotencyKey(ctx, input.UserID, input.IdempotencyKey)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check idempotency: %w", err)
 		}
 		if existing != nil {
-			// Return existing booking
-			return &types.BookingResponse{
-				BookingID: existing.ID,
-				Status:    "CONFIRMED",
-				Message:   "Booking already exists",
-			}, nil
+			// Return existing order
+			return orderResponse(existing, "Booking already exists"), nil
 		}
 	}
 
@@ -53,75 +56,213 @@ func (s *bookingService) CreateBooking(ctx context.Context, input *types.CreateB
 		}
 	}()
 
-	// Step 1: Lock the seat row using FOR UPDATE
-	seat, err := tx.GetSeatByIDForUpdate(ctx, input.SeatID)
-	if err != nil {
+	// Step 1: Resolve the seats to book; a hold books every seat it still holds
+	seatIDs := append([]uint(nil), input.SeatIDs...)
+	if input.HoldID != 0 {
+		holdLocks, err := s.locks.Inspect(ctx, tx, coretypes.SeatLockFilter{HoldID: input.HoldID})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("failed to inspect hold: %w", err)
+		}
+		if len(holdLocks) == 0 {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldExpired,
+				"hold has no locked seats or has expired")
+		}
+		if holdLocks[0].UserID != input.UserID {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeNotSeatHolder,
+				"only the holder can book this hold")
+		}
+		seatIDs = holdLocks[0].SeatIDs()
+	}
+	if len(seatIDs) == 0 {
 		tx.Rollback(ctx)
-		return nil, fmt.Errorf("failed to get seat: %w", err)
+		return nil, errors.NewHTTPErrorWithCode(http.StatusBadRequest, constants.ErrCodeBadRequest, "hold_id or seat_ids is required")
+	}
+	// Row locks are taken in seat ID order, the same order as Acquire
+	sort.Slice(seatIDs, func(i, j int) bool { return seatIDs[i] < seatIDs[j] })
+	for i := 1; i < len(seatIDs); i++ {
+		if seatIDs[i] == seatIDs[i-1] {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPErrorWithCode(http.StatusBadRequest, constants.ErrCodeBadRequest,
+				fmt.Sprintf("duplicate seat_id: %d", seatIDs[i]))
+		}
 	}
 
-	// Step 2: Validate
-	lockDuration := config.GetSeatLockDuration()
+	// Step 2: Lock and validate each seat row using FOR UPDATE
 	now := time.Now()
+	seats := make([]model.ShowSeat, 0, len(seatIDs))
+	holdIDs := make(map[uint]bool)
+	for _, seatID := range seatIDs {
+		seat, err := tx.GetSeatByIDForUpdate(ctx, seatID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPError(http.StatusNotFound, fmt.Sprintf("seat %d not found", seatID))
+		}
 
-	// Must be LOCKED
-	if seat.Status != string(constants.SeatStatusLocked) {
-		tx.Rollback(ctx)
-		return nil, fmt.Errorf("seat is not locked")
+		// Must not be sold already (an in-memory hold never changes the row)
+		if seat.Status == string(constants.SeatStatusSold) {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable,
+				fmt.Sprintf("seat %s is already sold", seat.SeatName))
+		}
+
+		// Must be held by an unexpired lock
+		locks, err := s.locks.Inspect(ctx, tx, coretypes.SeatLockFilter{SeatID: seatID})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("failed to inspect seat lock: %w", err)
+		}
+		if len(locks) == 0 {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatNotLocked,
+				fmt.Sprintf("seat %s is not locked or the lock has expired", seat.SeatName))
+		}
+
+		// User must match (only locker can buy)
+		if locks[0].UserID != input.UserID {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeNotSeatHolder,
+				fmt.Sprintf("seat %s is locked by another user", seat.SeatName))
+		}
+
+		// Verify show matches
+		if seat.ShowID != input.ShowID {
+			tx.Rollback(ctx)
+			return nil, errors.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("seat %s does not belong to this show", seat.SeatName))
+		}
+
+		seats = append(seats, *seat)
+		holdIDs[locks[0].HoldID] = true
 	}
 
-	// Lock must not be expired
-	if seat.LockedAt == nil || now.Sub(*seat.LockedAt) > lockDuration {
+	// Load the show to enforce its sales window and price the tickets
+	show, err := tx.GetShowByID(ctx, input.ShowID)
+	if err != nil {
 		tx.Rollback(ctx)
-		return nil, fmt.Errorf("seat lock has expired")
+		return nil, fmt.Errorf("failed to get show: %w", err)
 	}
-
-	// User must match (only locker can buy)
-	if seat.UserID == nil || *seat.UserID != input.UserID {
+	if err := checkSalesWindow(show, now); err != nil {
 		tx.Rollback(ctx)
-		return nil, fmt.Errorf("seat is locked by another user")
+		return nil, err
 	}
 
-	// Verify show matches
-	if seat.ShowID != input.ShowID {
+	// Step 3: Release the holds
+	if _, err := s.locks.Release(ctx, tx, coretypes.SeatLockRelease{
+		SeatIDs: seatIDs,
+		UserID:  input.UserID,
+		Reason:  "converted to booking",
+	}); err != nil {
 		tx.Rollback(ctx)
-		return nil, fmt.Errorf("seat does not belong to this show")
+		return nil, err
 	}
 
-	// Step 3: Update seat to SOLD
-	updates := map[string]interface{}{
-		"status":   string(constants.SeatStatusSold),
-		"locked_at": nil,
-		"user_id":   nil,
+	// A hold offered from the waitlist is claimed by booking from it
+	for holdID := range holdIDs {
+		if err := tx.ClaimWaitlistOffer(ctx, holdID); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
 	}
 
-	if err := tx.UpdateSeat(ctx, input.SeatID, updates); err != nil {
-		tx.Rollback(ctx)
-		return nil, fmt.Errorf("failed to update seat: %w", err)
+	// Step 4: Create the order with a booking per seat, awaiting payment when a payment timeout is set
+	status, message := constants.BookingStatusConfirmed, "Ticket sent to your email."
+	if timeout := config.GetBookingPaymentTimeout(); timeout > 0 {
+		status, message = constants.BookingStatusPendingPayment, fmt.Sprintf("Seats reserved, pay within %s to confirm.", timeout)
 	}
-
-	// Step 4: Create booking
-	booking := &model.Booking{
+	price := show.TicketPrice()
+	surcharge := price - show.BasePrice
+	order := &model.Order{
 		UserID:         input.UserID,
 		ShowID:         input.ShowID,
-		SeatID:         input.SeatID,
+		Status:         string(status),
 		IdempotencyKey: input.IdempotencyKey,
 	}
+	for _, seat := range seats {
+		// The price parts are kept so the breakdown survives later price changes
+		order.Bookings = append(order.Bookings, model.Booking{
+			UserID:          input.UserID,
+			ShowID:          input.ShowID,
+			SeatID:          seat.ID,
+			Status:          string(status),
+			Amount:          price,
+			BasePrice:       show.BasePrice,
+			FormatSurcharge: surcharge,
+		})
+		order.TotalAmount += price
+	}
 
-	booking, err = tx.CreateBooking(ctx, booking)
+	order, err = tx.CreateOrder(ctx, order)
 	if err != nil {
 		tx.Rollback(ctx)
-		return nil, fmt.Errorf("failed to create booking: %w", err)
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	if err := recordBookingsCreated(ctx, tx, order.Bookings, seatActor{userID: input.UserID}, "booking created"); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// Step 5: Update seats to SOLD, recording each booking in the seat history
+	for i := range order.Bookings {
+		booking := &order.Bookings[i]
+		released, err := tx.GetSeatByID(ctx, booking.SeatID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("failed to get seat: %w", err)
+		}
+
+		updates := map[string]interface{}{
+			"status":    string(constants.SeatStatusSold),
+			"locked_at": nil,
+			"user_id":   nil,
+			"hold_id":   nil,
+		}
+		event := newSeatEvent(released, constants.SeatTransitionSell, updates, seatActor{userID: input.UserID}, "booking created")
+		event.BookingID = &booking.ID
+
+		if err := tx.UpdateSeat(ctx, booking.SeatID, updates, event); err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("failed to update seat: %w", err)
+		}
+		booking.Seat = *released
 	}
 
-	// Step 5: Commit transaction
+	// Step 6: Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return &types.BookingResponse{
-		BookingID: booking.ID,
-		Status:    "CONFIRMED",
-		Message:   "Ticket sent to your email.",
-	}, nil
+	publishSeatEvents(s.events, constants.SeatEventSold, seats, nil, nil)
+
+	return orderResponse(order, message), nil
+}
+
+// orderResponse describes an order with its seats preloaded on each booking
+func orderResponse(order *model.Order, message string) *types.BookingResponse {
+	res := &types.BookingResponse{
+		OrderID: order.ID,
+		Status:  order.Status,
+		Amount:  order.TotalAmount,
+		Items:   make([]types.BookingLineItem, 0, len(order.Bookings)),
+		Message: message,
+	}
+	for _, booking := range order.Bookings {
+		res.Items = append(res.Items, types.BookingLineItem{
+			BookingID: booking.ID,
+			Status:    booking.Status,
+			SeatID:    booking.SeatID,
+			SeatName:  booking.Seat.SeatName,
+			Amount:    booking.Amount,
+		})
+	}
+	if len(order.Bookings) > 0 {
+		res.BookingID = order.Bookings[0].ID
+	}
+	if order.Status == string(constants.BookingStatusPendingPayment) {
+		dueAt := order.CreatedAt.Add(config.GetBookingPaymentTimeout())
+		res.PaymentDueAt = &dueAt
+	}
+	return res
 }
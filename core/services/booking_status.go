@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	"movie-booking/util/errors"
+)
+
+// bookingTransitions lists the statuses each booking status may move to; the empty status is a new booking.
+// Every booking status change goes through checkBookingTransition against this table.
+var bookingTransitions = map[constants.BookingStatus][]constants.BookingStatus{
+	"": {constants.BookingStatusPendingPayment, constants.BookingStatusConfirmed},
+	constants.BookingStatusPendingPayment: {
+		constants.BookingStatusConfirmed,
+		constants.BookingStatusCancelled,
+		constants.BookingStatusExpired,
+	},
+	constants.BookingStatusConfirmed: {
+		constants.BookingStatusCancelled,
+		constants.BookingStatusCheckedIn,
+	},
+	constants.BookingStatusCancelled: {constants.BookingStatusRefunded},
+}
+
+// checkBookingTransition rejects moving a booking from one status to another unless bookingTransitions allows it
+func checkBookingTransition(from string, to constants.BookingStatus) error {
+	for _, allowed := range bookingTransitions[constants.BookingStatus(from)] {
+		if allowed == to {
+			return nil
+		}
+	}
+	if from == "" {
+		return fmt.Errorf("bookings cannot be created as %s", to)
+	}
+	return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeInvalidBookingTransition,
+		fmt.Sprintf("booking is %s and cannot become %s", from, to))
+}
+
+// newBookingEvent describes moving booking to status for the booking_events history
+func newBookingEvent(booking *model.Booking, to constants.BookingStatus, actor seatActor, reason string) *model.BookingEvent {
+	event := &model.BookingEvent{
+		BookingID:  booking.ID,
+		FromStatus: booking.Status,
+		ToStatus:   string(to),
+		ActorType:  string(constants.SeatActorSystem),
+		Reason:     reason,
+	}
+	if actor.userID != 0 {
+		userID := actor.userID
+		event.ActorUserID = &userID
+		event.ActorType = string(constants.SeatActorUser)
+		if actor.isStaff && booking.UserID != actor.userID {
+			event.ActorType = string(constants.SeatActorStaff)
+		}
+	}
+	return event
+}
+
+// transitionBooking moves a booking, locked by the caller's transaction, to status along with any other column
+// updates, and records the transition
+func transitionBooking(ctx context.Context, tx model.DataStore, booking *model.Booking, to constants.BookingStatus,
+	updates map[string]interface{}, actor seatActor, reason string) error {
+	if err := checkBookingTransition(booking.Status, to); err != nil {
+		return err
+	}
+
+	if updates == nil {
+		updates = map[string]interface{}{}
+	}
+	updates["status"] = string(to)
+	if err := tx.UpdateBooking(ctx, booking.ID, updates, newBookingEvent(booking, to, actor, reason)); err != nil {
+		return err
+	}
+	booking.Status = string(to)
+	return nil
+}
+
+// recordBookingsCreated records the initial status of newly created bookings
+func recordBookingsCreated(ctx context.Context, tx model.DataStore, bookings []model.Booking, actor seatActor, reason string) error {
+	bookingEvents := make([]model.BookingEvent, 0, len(bookings))
+	for i := range bookings {
+		status := bookings[i].Status
+		if err := checkBookingTransition("", constants.BookingStatus(status)); err != nil {
+			return err
+		}
+		created := bookings[i]
+		created.Status = ""
+		bookingEvents = append(bookingEvents, *newBookingEvent(&created, constants.BookingStatus(status), actor, reason))
+	}
+	return tx.CreateBookingEvents(ctx, bookingEvents)
+}
+
+// orderStatusPriority decides an order's status from its bookings: the first status any of them has wins, so an
+// order stays live while one booking is, and stays CANCELLED until every cancelled booking is refunded
+var orderStatusPriority = []constants.BookingStatus{
+	constants.BookingStatusPendingPayment,
+	constants.BookingStatusConfirmed,
+	constants.BookingStatusCheckedIn,
+	constants.BookingStatusCancelled,
+	constants.BookingStatusRefunded,
+	constants.BookingStatusExpired,
+}
+
+// syncOrderStatus sets an order's status from its bookings after one of them changed; the caller's transaction
+// must hold the order row lock, which every booking status change takes before the booking
+func syncOrderStatus(ctx context.Context, tx model.DataStore, order *model.Order) error {
+	bookings, err := tx.GetBookingsByOrderIDForUpdate(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order bookings: %w", err)
+	}
+
+	present := make(map[string]bool, len(bookings))
+	for _, booking := range bookings {
+		present[booking.Status] = true
+	}
+	for _, status := range orderStatusPriority {
+		if !present[string(status)] {
+			continue
+		}
+		if order.Status == string(status) {
+			return nil
+		}
+		if err := tx.UpdateOrder(ctx, order.ID, map[string]interface{}{"status": string(status)}); err != nil {
+			return err
+		}
+		order.Status = string(status)
+		return nil
+	}
+	return nil
+}
+
+// CheckInBooking marks a confirmed booking as used at the door
+func (s *bookingService) CheckInBooking(ctx context.Context, bookingID uint, staffUserID uint) (*types.BookingStatusResponse, error) {
+	return s.changeBookingStatus(ctx, bookingID, constants.BookingStatusCheckedIn, seatActor{userID: staffUserID, isStaff: true}, "checked in")
+}
+
+// MarkBookingRefunded records that the refund owed for a cancelled booking has been paid out
+func (s *bookingService) MarkBookingRefunded(ctx context.Context, bookingID uint, staffUserID uint) (*types.BookingStatusResponse, error) {
+	return s.changeBookingStatus(ctx, bookingID, constants.BookingStatusRefunded, seatActor{userID: staffUserID, isStaff: true}, "refund paid")
+}
+
+// changeBookingStatus moves a booking to status in its own transaction
+func (s *bookingService) changeBookingStatus(ctx context.Context, bookingID uint, to constants.BookingStatus, actor seatActor, reason string) (*types.BookingStatusResponse, error) {
+	// Begin transaction
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback on panic or error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback(ctx)
+			panic(r)
+		}
+	}()
+
+	// Step 1: Lock the order, then the booking row using FOR UPDATE
+	order, err := tx.GetOrderByBookingIDForUpdate(ctx, bookingID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.NewHTTPError(http.StatusNotFound, "booking not found")
+	}
+
+	booking, err := tx.GetBookingByIDForUpdate(ctx, bookingID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.NewHTTPError(http.StatusNotFound, "booking not found")
+	}
+
+	// Step 2: Validate and apply the transition, then let the order follow
+	from := booking.Status
+	if err := transitionBooking(ctx, tx, booking, to, nil, actor, reason); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := syncOrderStatus(ctx, tx, order); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// Step 3: Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &types.BookingStatusResponse{
+		BookingID:  booking.ID,
+		OrderID:    booking.OrderID,
+		FromStatus: from,
+		Status:     booking.Status,
+		Message:    fmt.Sprintf("Booking %s", strings.ToLower(strings.ReplaceAll(booking.Status, "_", " "))),
+	}, nil
+}
+
+// ConfirmOrderPayment records that payment arrived for an order awaiting it, confirming the bookings still pending
+func (s *bookingService) ConfirmOrderPayment(ctx context.Context, orderID uint, staffUserID uint) (*types.BookingResponse, error) {
+	// Begin transaction
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback on panic or error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback(ctx)
+			panic(r)
+		}
+	}()
+
+	// Step 1: Lock the order, then its bookings
+	order, err := tx.GetOrderByIDForUpdate(ctx, orderID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.NewHTTPError(http.StatusNotFound, "order not found")
+	}
+
+	bookings, err := tx.GetBookingsByOrderIDForUpdate(ctx, order.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("failed to get order bookings: %w", err)
+	}
+
+	// Step 2: Confirm the bookings awaiting payment; ones cancelled in the meantime stay cancelled
+	actor := seatActor{userID: staffUserID, isStaff: true}
+	confirmed := 0
+	for i := range bookings {
+		if bookings[i].Status != string(constants.BookingStatusPendingPayment) {
+			continue
+		}
+		if err := transitionBooking(ctx, tx, &bookings[i], constants.BookingStatusConfirmed, nil, actor, "payment received"); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		confirmed++
+	}
+	if confirmed == 0 {
+		tx.Rollback(ctx)
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeInvalidBookingTransition,
+			fmt.Sprintf("order is %s and has no bookings awaiting payment", order.Status))
+	}
+
+	if err := syncOrderStatus(ctx, tx, order); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// Step 3: Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	order.Bookings = bookings
+	return orderResponse(order, "Payment received, booking confirmed."), nil
+}
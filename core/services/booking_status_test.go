@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/util/errors"
+)
+
+var allBookingStatuses = []constants.BookingStatus{
+	constants.BookingStatusPendingPayment,
+	constants.BookingStatusConfirmed,
+	constants.BookingStatusCancelled,
+	constants.BookingStatusRefunded,
+	constants.BookingStatusCheckedIn,
+	constants.BookingStatusExpired,
+}
+
+func TestCheckBookingTransition(t *testing.T) {
+	allowed := map[constants.BookingStatus][]constants.BookingStatus{
+		"": {constants.BookingStatusPendingPayment, constants.BookingStatusConfirmed},
+		constants.BookingStatusPendingPayment: {
+			constants.BookingStatusConfirmed, constants.BookingStatusCancelled, constants.BookingStatusExpired,
+		},
+		constants.BookingStatusConfirmed: {constants.BookingStatusCancelled, constants.BookingStatusCheckedIn},
+		constants.BookingStatusCancelled: {constants.BookingStatusRefunded},
+	}
+
+	for _, from := range append([]constants.BookingStatus{""}, allBookingStatuses...) {
+		for _, to := range allBookingStatuses {
+			want := false
+			for _, status := range allowed[from] {
+				want = want || status == to
+			}
+
+			err := checkBookingTransition(string(from), to)
+			switch {
+			case want && err != nil:
+				t.Errorf("%q -> %s refused: %v", from, to, err)
+			case !want && err == nil:
+				t.Errorf("%q -> %s allowed", from, to)
+			case !want && from != "":
+				httpErr, ok := errors.IsHTTPError(err)
+				if !ok || httpErr.Code != constants.ErrCodeInvalidBookingTransition {
+					t.Errorf("%s -> %s: want %s, got %v", from, to, constants.ErrCodeInvalidBookingTransition, err)
+				}
+			case !want:
+				// A new booking in the wrong status is a programming error, not a client error
+				if _, ok := errors.IsHTTPError(err); ok {
+					t.Errorf("creating a %s booking returned an HTTP error: %v", to, err)
+				}
+			}
+		}
+	}
+}
+
+func TestSyncOrderStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    constants.BookingStatus
+		bookings []constants.BookingStatus
+		want     constants.BookingStatus
+	}{
+		{"one unpaid booking keeps the order pending", constants.BookingStatusPendingPayment,
+			[]constants.BookingStatus{constants.BookingStatusPendingPayment, constants.BookingStatusCancelled}, constants.BookingStatusPendingPayment},
+		{"a live booking keeps the order confirmed", constants.BookingStatusConfirmed,
+			[]constants.BookingStatus{constants.BookingStatusCancelled, constants.BookingStatusConfirmed}, constants.BookingStatusConfirmed},
+		{"confirmed outranks checked in", constants.BookingStatusConfirmed,
+			[]constants.BookingStatus{constants.BookingStatusCheckedIn, constants.BookingStatusConfirmed}, constants.BookingStatusConfirmed},
+		{"checked in once nothing is confirmed", constants.BookingStatusConfirmed,
+			[]constants.BookingStatus{constants.BookingStatusCheckedIn, constants.BookingStatusRefunded}, constants.BookingStatusCheckedIn},
+		{"cancelled until every refund is paid", constants.BookingStatusConfirmed,
+			[]constants.BookingStatus{constants.BookingStatusRefunded, constants.BookingStatusCancelled}, constants.BookingStatusCancelled},
+		{"refunded once every refund is paid", constants.BookingStatusCancelled,
+			[]constants.BookingStatus{constants.BookingStatusRefunded, constants.BookingStatusExpired}, constants.BookingStatusRefunded},
+		{"expired when payment never arrived", constants.BookingStatusPendingPayment,
+			[]constants.BookingStatus{constants.BookingStatusExpired, constants.BookingStatusExpired}, constants.BookingStatusExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeOrderStore(testShowID, len(tt.bookings))
+			statuses := make(map[uint]constants.BookingStatus, len(tt.bookings))
+			for i, status := range tt.bookings {
+				statuses[uint(i+1)] = status
+			}
+			order := store.addOrder(tt.order, time.Now(), statuses)
+
+			if err := syncOrderStatus(context.Background(), store, order); err != nil {
+				t.Fatalf("syncOrderStatus: %v", err)
+			}
+			if order.Status != string(tt.want) || store.orderStatus(order.ID) != string(tt.want) {
+				t.Fatalf("order is %s (stored %s), want %s", order.Status, store.orderStatus(order.ID), tt.want)
+			}
+			wantUpdates := 1
+			if tt.order == tt.want {
+				wantUpdates = 0
+			}
+			if updates := store.orderData.orderUpdates; updates != wantUpdates {
+				t.Fatalf("order written %d times, want %d", updates, wantUpdates)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"movie-booking/api/v1/types"
+	"movie-booking/core/events"
 	"movie-booking/core/model"
 )
 
@@ -20,17 +21,53 @@ type MovieServiceInterface interface {
 
 // ShowServiceInterface defines show operations
 type ShowServiceInterface interface {
-	GetShowsByMovieID(ctx context.Context, movieID uint) ([]model.Show, error)
+	GetShowsByMovieID(ctx context.Context, movieID uint, filter model.ShowFilter) ([]model.Show, error)
 	GetShowByID(ctx context.Context, id uint) (*model.Show, error)
 }
 
 // SeatServiceInterface defines seat operations
 type SeatServiceInterface interface {
 	GetSeatsByShowID(ctx context.Context, showID uint) ([]model.ShowSeat, error)
+	GetSeatMap(ctx context.Context, showID uint) (*types.SeatMapResponse, error)
+	GetSeatMapVersion(ctx context.Context, showID uint) (uint64, error)
 	LockSeat(ctx context.Context, seatID uint, userID uint) (*types.LockSeatResponse, error)
+	LockSeats(ctx context.Context, showID uint, seatIDs []uint, userID uint) (*types.LockSeatResponse, error)
+	LockBestAvailable(ctx context.Context, showID uint, quantity int, category string, userID uint) (*types.LockSeatResponse, error)
+	ReleaseSeat(ctx context.Context, seatID uint, userID uint, isStaff bool) (*types.ReleaseSeatResponse, error)
+	ReleaseHold(ctx context.Context, holdID uint, userID uint, isStaff bool) (*types.ReleaseSeatResponse, error)
+	RenewHold(ctx context.Context, holdID uint, userID uint) (*types.LockSeatResponse, error)
+	SubscribeSeatEvents(ctx context.Context, showID uint, lastEventID uint64) (*events.Subscription, []events.SeatEvent, error)
+	GetSeatTimeline(ctx context.Context, seatID uint) (*types.SeatTimelineResponse, error)
+	BlockSeats(ctx context.Context, showID uint, seatIDs []uint, reason string, staffID uint) (*types.SeatBlockResponse, error)
+	UnblockSeats(ctx context.Context, showID uint, seatIDs []uint, reason string, staffID uint) (*types.SeatBlockResponse, error)
+	BlockScreenSeats(ctx context.Context, screenID uint, seatNames []string, reason string, staffID uint) (*types.SeatBlockResponse, error)
+	UnblockScreenSeats(ctx context.Context, screenID uint, seatNames []string, reason string, staffID uint) (*types.SeatBlockResponse, error)
 }
 
 // BookingServiceInterface defines booking operations
 type BookingServiceInterface interface {
 	CreateBooking(ctx context.Context, input *types.CreateBookingInput) (*types.BookingResponse, error)
+	ListBookings(ctx context.Context, userID uint, req *types.ListBookingsRequest) (*types.BookingListResponse, error)
+	GetBooking(ctx context.Context, bookingID uint, userID uint, isStaff bool) (*types.BookingDetailResponse, error)
+	CancelBooking(ctx context.Context, bookingID uint, userID uint, isStaff bool) (*types.CancelBookingResponse, error)
+	CheckInBooking(ctx context.Context, bookingID uint, staffUserID uint) (*types.BookingStatusResponse, error)
+	MarkBookingRefunded(ctx context.Context, bookingID uint, staffUserID uint) (*types.BookingStatusResponse, error)
+	ConfirmOrderPayment(ctx context.Context, orderID uint, staffUserID uint) (*types.BookingResponse, error)
+}
+
+// WaitingRoomServiceInterface defines waiting room operations
+type WaitingRoomServiceInterface interface {
+	SetWaitingRoom(ctx context.Context, showID uint, enabled bool) (*types.WaitingRoomSettingsResponse, error)
+	JoinWaitingRoom(ctx context.Context, showID uint, userID uint) (*types.WaitingRoomStatusResponse, error)
+	GetWaitingRoomStatus(ctx context.Context, showID uint, userID uint) (*types.WaitingRoomStatusResponse, error)
+	LeaveWaitingRoom(ctx context.Context, showID uint, userID uint) error
+	CheckAdmission(ctx context.Context, showID uint, userID uint, token string) error
+	CheckSeatAdmission(ctx context.Context, seatID uint, userID uint, token string) error
+}
+
+// WaitlistServiceInterface defines waitlist operations
+type WaitlistServiceInterface interface {
+	JoinWaitlist(ctx context.Context, showID uint, userID uint, seatCount int) (*types.WaitlistStatusResponse, error)
+	GetWaitlistStatus(ctx context.Context, showID uint, userID uint) (*types.WaitlistStatusResponse, error)
+	LeaveWaitlist(ctx context.Context, showID uint, userID uint) error
 }
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/events"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// lockSweeperAdvisoryLock names the MySQL lock that keeps a single replica sweeping at a time
+const lockSweeperAdvisoryLock = "movie_booking.seat_lock_sweeper"
+
+// LockSweeper periodically persists expired seat locks as AVAILABLE
+type LockSweeper struct {
+	store         model.DataStore
+	events        *events.Hub
+	waitlist      coretypes.SeatAvailabilityListener
+	totalReleased int64
+}
+
+// NewLockSweeper creates a new expired seat lock sweeper
+func NewLockSweeper(clients *coretypes.Clients, store model.DataStore) *LockSweeper {
+	return &LockSweeper{store: store, events: clients.SeatEvents, waitlist: clients.Waitlist}
+}
+
+// Start runs the sweeper in the background until ctx is cancelled
+func (w *LockSweeper) Start(ctx context.Context) {
+	interval := config.GetSeatLockSweepInterval()
+	logrus.WithField("interval", interval.String()).Info("Starting seat lock sweeper")
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logrus.Info("Seat lock sweeper stopped")
+				return
+			case <-ticker.C:
+				if _, err := w.Sweep(ctx); err != nil {
+					logrus.WithError(err).Error("Seat lock sweep failed")
+				}
+			}
+		}
+	}()
+}
+
+// TotalReleased returns how many seats this process has released since start
+func (w *LockSweeper) TotalReleased() int64 {
+	return atomic.LoadInt64(&w.totalReleased)
+}
+
+// Sweep releases every expired lock in batches, provided no other replica is sweeping
+func (w *LockSweeper) Sweep(ctx context.Context) (int, error) {
+	release, acquired, err := w.store.TryAdvisoryLock(ctx, lockSweeperAdvisoryLock)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		logrus.Debug("Seat lock sweep skipped, another instance holds the sweeper lock")
+		return 0, nil
+	}
+	defer release()
+
+	batchSize := config.GetSeatLockSweepBatchSize()
+	total := 0
+	for {
+		released, err := w.sweepBatch(ctx, batchSize)
+		total += released
+		if err != nil {
+			return total, err
+		}
+		if released < batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		atomic.AddInt64(&w.totalReleased, int64(total))
+		logrus.WithFields(logrus.Fields{
+			"released":       total,
+			"total_released": w.TotalReleased(),
+		}).Info("Released expired seat locks")
+	}
+	return total, nil
+}
+
+// sweepBatch releases up to batchSize expired locks in one short transaction
+func (w *LockSweeper) sweepBatch(ctx context.Context, batchSize int) (int, error) {
+	tx, err := w.store.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback on panic or error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback(ctx)
+			panic(r)
+		}
+	}()
+
+	lockedBefore := time.Now().Add(-config.GetSeatLockDuration())
+	seats, err := tx.GetExpiredSeatLocksForUpdate(ctx, lockedBefore, batchSize)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+
+	for _, seat := range seats {
+		event := newSeatEvent(&seat, constants.SeatTransitionExpire, releasedSeatUpdates(), systemActor, "lock expired")
+		if err := tx.UpdateSeat(ctx, seat.ID, releasedSeatUpdates(), event); err != nil {
+			tx.Rollback(ctx)
+			return 0, fmt.Errorf("failed to release expired seat %d: %w", seat.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	publishSeatEvents(w.events, constants.SeatEventExpired, seats, nil, nil)
+	notifySeatsFreed(w.waitlist, seats)
+	return len(seats), nil
+}
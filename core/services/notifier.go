@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	coretypes "movie-booking/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// logNotifier writes notifications to the log, standing in until a delivery channel is wired in
+type logNotifier struct{}
+
+// NewLogNotifier creates a notifier that only logs
+func NewLogNotifier() coretypes.Notifier {
+	return &logNotifier{}
+}
+
+// Notify logs the notification
+func (n *logNotifier) Notify(ctx context.Context, notification coretypes.Notification) error {
+	logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID": notification.UserID,
+		"type":   notification.Type,
+		"data":   notification.Data,
+	}).Info("[Notify] ", notification.Message)
+	return nil
+}
+
+// notifier returns the injected notifier, defaulting to the log
+func notifier(clients *coretypes.Clients) coretypes.Notifier {
+	if clients.Notifier != nil {
+		return clients.Notifier
+	}
+	return NewLogNotifier()
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/model"
+)
+
+// fakeOrderData is the shared order and booking state behind every fakeOrderStore of one test
+type fakeOrderData struct {
+	mu            sync.Mutex
+	orders        map[uint]*model.Order
+	bookings      map[uint]*model.Booking
+	bookingEvents []model.BookingEvent
+	orderUpdates  int
+}
+
+// fakeOrderStore adds the order and booking parts of model.DataStore to fakeSeatStore. Order and booking rows
+// are not locked; tests using it run one transaction at a time.
+type fakeOrderStore struct {
+	*fakeSeatStore
+	orderData *fakeOrderData
+}
+
+// newFakeOrderStore creates a store holding seatCount AVAILABLE seats of showID and no orders
+func newFakeOrderStore(showID uint, seatCount int) *fakeOrderStore {
+	return &fakeOrderStore{
+		fakeSeatStore: newFakeSeatStore(showID, seatCount),
+		orderData: &fakeOrderData{
+			orders:   make(map[uint]*model.Order),
+			bookings: make(map[uint]*model.Booking),
+		},
+	}
+}
+
+func (s *fakeOrderStore) Begin(ctx context.Context) (model.DataStore, error) {
+	tx, err := s.fakeSeatStore.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeOrderStore{fakeSeatStore: tx.(*fakeSeatStore), orderData: s.orderData}, nil
+}
+
+func (s *fakeOrderStore) TryAdvisoryLock(ctx context.Context, name string) (func(), bool, error) {
+	return func() {}, true, nil
+}
+
+// addOrder stores an order created at createdAt with a booking of the given status per seat, selling the seats
+func (s *fakeOrderStore) addOrder(status constants.BookingStatus, createdAt time.Time, statuses map[uint]constants.BookingStatus) *model.Order {
+	s.orderData.mu.Lock()
+	defer s.orderData.mu.Unlock()
+
+	order := &model.Order{
+		ID:        uint(len(s.orderData.orders) + 1),
+		ShowID:    testShowID,
+		UserID:    1,
+		Status:    string(status),
+		CreatedAt: createdAt,
+	}
+	s.orderData.orders[order.ID] = order
+	seatIDs := make([]uint, 0, len(statuses))
+	for seatID := range statuses {
+		seatIDs = append(seatIDs, seatID)
+	}
+	sort.Slice(seatIDs, func(i, j int) bool { return seatIDs[i] < seatIDs[j] })
+	for _, seatID := range seatIDs {
+		booking := &model.Booking{
+			ID:      uint(len(s.orderData.bookings) + 1),
+			OrderID: order.ID,
+			ShowID:  testShowID,
+			UserID:  1,
+			SeatID:  seatID,
+			Status:  string(statuses[seatID]),
+		}
+		s.orderData.bookings[booking.ID] = booking
+		sold := map[string]interface{}{"status": string(constants.SeatStatusSold)}
+		if err := s.fakeSeatStore.UpdateSeat(context.Background(), seatID, sold, nil); err != nil {
+			panic(err)
+		}
+	}
+	copied := *order
+	return &copied
+}
+
+func (s *fakeOrderStore) orderStatus(id uint) string {
+	s.orderData.mu.Lock()
+	defer s.orderData.mu.Unlock()
+	return s.orderData.orders[id].Status
+}
+
+func (s *fakeOrderStore) bookingStatus(id uint) string {
+	s.orderData.mu.Lock()
+	defer s.orderData.mu.Unlock()
+	return s.orderData.bookings[id].Status
+}
+
+func (s *fakeOrderStore) GetExpiredPendingOrdersForUpdate(ctx context.Context, createdBefore time.Time, limit int) ([]model.Order, error) {
+	s.orderData.mu.Lock()
+	defer s.orderData.mu.Unlock()
+	orders := []model.Order{}
+	for _, order := range s.orderData.orders {
+		if order.Status == string(constants.BookingStatusPendingPayment) && order.CreatedAt.Before(createdBefore) {
+			orders = append(orders, *order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+	if len(orders) > limit {
+		orders = orders[:limit]
+	}
+	return orders, nil
+}
+
+func (s *fakeOrderStore) GetBookingsByOrderIDForUpdate(ctx context.Context, orderID uint) ([]model.Booking, error) {
+	s.orderData.mu.Lock()
+	defer s.orderData.mu.Unlock()
+	bookings := []model.Booking{}
+	for _, booking := range s.orderData.bookings {
+		if booking.OrderID == orderID {
+			bookings = append(bookings, *booking)
+		}
+	}
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].ID < bookings[j].ID })
+	return bookings, nil
+}
+
+func (s *fakeOrderStore) UpdateOrder(ctx context.Context, id uint, updates map[string]interface{}) error {
+	s.orderData.mu.Lock()
+	defer s.orderData.mu.Unlock()
+	order, ok := s.orderData.orders[id]
+	if !ok {
+		return fmt.Errorf("order not found or no changes made")
+	}
+	for column, value := range updates {
+		switch column {
+		case "status":
+			order.Status = value.(string)
+		default:
+			panic(fmt.Sprintf("fake store cannot update orders.%s", column))
+		}
+	}
+	s.orderData.orderUpdates++
+	return nil
+}
+
+func (s *fakeOrderStore) UpdateBooking(ctx context.Context, id uint, updates map[string]interface{}, event *model.BookingEvent) error {
+	s.orderData.mu.Lock()
+	defer s.orderData.mu.Unlock()
+	booking, ok := s.orderData.bookings[id]
+	if !ok {
+		return fmt.Errorf("booking not found or no changes made")
+	}
+	for column, value := range updates {
+		switch column {
+		case "status":
+			booking.Status = value.(string)
+		default:
+			panic(fmt.Sprintf("fake store cannot update bookings.%s", column))
+		}
+	}
+	if event != nil {
+		s.orderData.bookingEvents = append(s.orderData.bookingEvents, *event)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/events"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// paymentExpirerAdvisoryLock names the MySQL lock that keeps a single replica expiring orders at a time
+const paymentExpirerAdvisoryLock = "movie_booking.payment_expirer"
+
+// paymentExpiryBatchSize bounds how many orders one expiry transaction locks
+const paymentExpiryBatchSize = 100
+
+// PaymentExpirer periodically expires orders still awaiting payment after BOOKING_PAYMENT_TIMEOUT, putting their
+// seats back on sale. It runs whatever the seat lock backend, since pending orders hold SOLD seat rows.
+type PaymentExpirer struct {
+	store    model.DataStore
+	events   *events.Hub
+	waitlist coretypes.SeatAvailabilityListener
+}
+
+// NewPaymentExpirer creates a new unpaid order expirer
+func NewPaymentExpirer(clients *coretypes.Clients, store model.DataStore) *PaymentExpirer {
+	return &PaymentExpirer{store: store, events: clients.SeatEvents, waitlist: clients.Waitlist}
+}
+
+// StartBackgroundWorkers starts the workers that expire holds and unpaid orders, each only when configured. They
+// run until ctx is cancelled.
+func StartBackgroundWorkers(ctx context.Context, clients *coretypes.Clients, store model.DataStore) {
+	// Persist expired seat locks (one replica at a time); in-memory holds expire on their own
+	if config.GetSeatLockSweeperEnabled() && config.GetSeatLockBackend() != string(constants.SeatLockBackendMemory) {
+		NewLockSweeper(clients, store).Start(ctx)
+	}
+	if config.GetBookingPaymentTimeout() > 0 {
+		NewPaymentExpirer(clients, store).Start(ctx)
+	}
+}
+
+// Start runs the expirer in the background until ctx is cancelled
+func (w *PaymentExpirer) Start(ctx context.Context) {
+	interval := config.GetBookingPaymentExpiryInterval()
+	logrus.WithField("interval", interval.String()).Info("Starting payment expirer")
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logrus.Info("Payment expirer stopped")
+				return
+			case <-ticker.C:
+				if _, err := w.Expire(ctx); err != nil {
+					logrus.WithError(err).Error("Payment expiry failed")
+				}
+			}
+		}
+	}()
+}
+
+// Expire expires every order created more than BOOKING_PAYMENT_TIMEOUT ago that is still awaiting payment, in
+// batches, provided no other replica is expiring orders. It returns how many seats went back on sale.
+func (w *PaymentExpirer) Expire(ctx context.Context) (int, error) {
+	release, acquired, err := w.store.TryAdvisoryLock(ctx, paymentExpirerAdvisoryLock)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		logrus.Debug("Payment expiry skipped, another instance holds the expirer lock")
+		return 0, nil
+	}
+	defer release()
+
+	timeout := config.GetBookingPaymentTimeout()
+	orders, total := 0, 0
+	for {
+		expired, freed, err := w.expireBatch(ctx, time.Now().Add(-timeout), paymentExpiryBatchSize)
+		orders += expired
+		total += freed
+		if err != nil {
+			return total, err
+		}
+		if expired < paymentExpiryBatchSize {
+			break
+		}
+	}
+
+	if orders > 0 {
+		logrus.WithFields(logrus.Fields{
+			"orders": orders,
+			"seats":  total,
+		}).Info("Expired unpaid orders")
+	}
+	return total, nil
+}
+
+// expireBatch expires up to batchSize unpaid orders in one short transaction, putting the seats of their
+// pending bookings back on sale the way a cancellation does
+func (w *PaymentExpirer) expireBatch(ctx context.Context, createdBefore time.Time, batchSize int) (int, int, error) {
+	tx, err := w.store.Begin(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback on panic or error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback(ctx)
+			panic(r)
+		}
+	}()
+
+	orders, err := tx.GetExpiredPendingOrdersForUpdate(ctx, createdBefore, batchSize)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, 0, err
+	}
+
+	var seats []model.ShowSeat
+	for i := range orders {
+		bookings, err := tx.GetBookingsByOrderIDForUpdate(ctx, orders[i].ID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, 0, fmt.Errorf("failed to get order bookings: %w", err)
+		}
+
+		for j := range bookings {
+			booking := &bookings[j]
+			if booking.Status != string(constants.BookingStatusPendingPayment) {
+				continue
+			}
+			if err := transitionBooking(ctx, tx, booking, constants.BookingStatusExpired, nil, systemActor, "payment not received"); err != nil {
+				tx.Rollback(ctx)
+				return 0, 0, err
+			}
+			seat, err := releaseBookedSeat(ctx, tx, booking, constants.SeatTransitionExpire, systemActor, "payment not received")
+			if err != nil {
+				tx.Rollback(ctx)
+				return 0, 0, err
+			}
+			seats = append(seats, *seat)
+		}
+
+		if err := syncOrderStatus(ctx, tx, &orders[i]); err != nil {
+			tx.Rollback(ctx)
+			return 0, 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	publishSeatEvents(w.events, constants.SeatEventExpired, seats, nil, nil)
+	notifySeatsFreed(w.waitlist, seats)
+	return len(orders), len(seats), nil
+}
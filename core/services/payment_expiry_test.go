@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"movie-booking/constants"
+	coretypes "movie-booking/core/types"
+)
+
+func TestPaymentExpiryRunsWithoutLockSweeper(t *testing.T) {
+	setupSeatLockConfig(t, map[string]string{
+		"SEAT_LOCK_SWEEPER_ENABLED":       "false",
+		"SEAT_LOCK_BACKEND":               "memory",
+		"BOOKING_PAYMENT_TIMEOUT":         "10m",
+		"BOOKING_PAYMENT_EXPIRY_INTERVAL": "10ms",
+	})
+	store := newFakeOrderStore(testShowID, 3)
+	now := time.Now()
+	pending := constants.BookingStatusPendingPayment
+	unpaid := store.addOrder(pending, now.Add(-time.Hour), map[uint]constants.BookingStatus{
+		1: pending,
+		2: pending,
+	})
+	recent := store.addOrder(pending, now, map[uint]constants.BookingStatus{3: pending})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartBackgroundWorkers(ctx, &coretypes.Clients{}, store)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for store.orderStatus(unpaid.ID) != string(constants.BookingStatusExpired) {
+		if time.Now().After(deadline) {
+			t.Fatalf("unpaid order is %s, want EXPIRED", store.orderStatus(unpaid.ID))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	for _, bookingID := range []uint{1, 2} {
+		if status := store.bookingStatus(bookingID); status != string(constants.BookingStatusExpired) {
+			t.Fatalf("booking %d is %s, want EXPIRED", bookingID, status)
+		}
+	}
+	for seatID := uint(1); seatID <= 2; seatID++ {
+		seat, _ := store.GetSeatByID(context.Background(), seatID)
+		if seat.Status != string(constants.SeatStatusAvailable) {
+			t.Fatalf("seat %d of the expired order is %s, want AVAILABLE", seatID, seat.Status)
+		}
+	}
+	if status := store.orderStatus(recent.ID); status != string(constants.BookingStatusPendingPayment) {
+		t.Fatalf("order still within its payment timeout is %s, want PENDING_PAYMENT", status)
+	}
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	"movie-booking/util/errors"
+)
+
+// salesCloseAt returns when ticket sales end for a show, falling back to the configured cutoff before start
+func salesCloseAt(show *model.Show) time.Time {
+	if show.SalesCloseAt != nil {
+		return *show.SalesCloseAt
+	}
+	return show.StartTime.Add(-config.GetSalesCloseBeforeStart())
+}
+
+// checkSalesWindow rejects seat operations outside the show's sales window
+func checkSalesWindow(show *model.Show, now time.Time) error {
+	if show.SalesOpenAt != nil && now.Before(*show.SalesOpenAt) {
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSalesNotOpen,
+			"ticket sales for this show have not opened yet")
+	}
+	if !now.Before(salesCloseAt(show)) {
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSalesClosed,
+			"ticket sales for this show have closed")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	"movie-booking/util/errors"
+)
+
+// seatActor is who causes a seat transition; a zero userID is the system
+type seatActor struct {
+	userID  uint
+	isStaff bool
+}
+
+// systemActor is the actor of background transitions such as lock expiry
+var systemActor = seatActor{}
+
+// newSeatEvent describes applying updates to seat, as read just before the write, for the seat_events history.
+// Holder fields missing from updates keep their current values.
+func newSeatEvent(seat *model.ShowSeat, transition constants.SeatTransition, updates map[string]interface{}, actor seatActor, reason string) *model.SeatEvent {
+	event := &model.SeatEvent{
+		ShowSeatID: seat.ID,
+		ShowID:     seat.ShowID,
+		Transition: string(transition),
+		FromStatus: seat.Status,
+		ToStatus:   seat.Status,
+		PrevUserID: seat.UserID,
+		PrevHoldID: seat.HoldID,
+		UserID:     seat.UserID,
+		HoldID:     seat.HoldID,
+		ActorType:  string(constants.SeatActorSystem),
+		Reason:     reason,
+	}
+	if status, ok := updates["status"].(string); ok {
+		event.ToStatus = status
+	}
+	if value, ok := updates["user_id"]; ok {
+		event.UserID = optionalUint(value)
+	}
+	if value, ok := updates["hold_id"]; ok {
+		event.HoldID = optionalUint(value)
+	}
+
+	if actor.userID != 0 {
+		userID := actor.userID
+		event.ActorUserID = &userID
+		event.ActorType = string(constants.SeatActorUser)
+		if actor.isStaff && (seat.UserID == nil || *seat.UserID != actor.userID) {
+			event.ActorType = string(constants.SeatActorStaff)
+		}
+	}
+	return event
+}
+
+// optionalUint reads a nullable ID column value from an updates map
+func optionalUint(value interface{}) *uint {
+	switch v := value.(type) {
+	case uint:
+		return &v
+	case *uint:
+		return v
+	}
+	return nil
+}
+
+// releaseReason explains a release in the seat history when the caller gave no reason
+func releaseReason(seat *model.ShowSeat, actor seatActor, reason string) string {
+	switch {
+	case reason != "":
+		return reason
+	case seat.UserID != nil && *seat.UserID == actor.userID:
+		return "released by holder"
+	default:
+		return "released by staff"
+	}
+}
+
+// GetSeatTimeline returns a seat with every recorded transition, oldest first
+func (s *seatService) GetSeatTimeline(ctx context.Context, seatID uint) (*types.SeatTimelineResponse, error) {
+	seat, err := s.store.GetSeatByID(ctx, seatID)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "seat not found")
+	}
+
+	seatEvents, err := s.store.GetSeatEventsBySeatID(ctx, seatID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SeatTimelineResponse{
+		Seat:   *seat,
+		Events: seatEvents,
+	}, nil
+}
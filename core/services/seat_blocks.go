@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+)
+
+// BlockSeats takes seats of one show out of sale. Every seat must be free or already blocked, otherwise nothing changes.
+func (s *seatService) BlockSeats(ctx context.Context, showID uint, seatIDs []uint, reason string, staffID uint) (*types.SeatBlockResponse, error) {
+	return s.changeShowSeatBlocks(ctx, showID, seatIDs, true, reason, staffID)
+}
+
+// UnblockSeats puts blocked seats of one show back on sale. Every seat must be blocked, otherwise nothing changes.
+func (s *seatService) UnblockSeats(ctx context.Context, showID uint, seatIDs []uint, reason string, staffID uint) (*types.SeatBlockResponse, error) {
+	return s.changeShowSeatBlocks(ctx, showID, seatIDs, false, reason, staffID)
+}
+
+// BlockScreenSeats blocks the named seats on every upcoming show of a screen, skipping seats that are sold or held
+func (s *seatService) BlockScreenSeats(ctx context.Context, screenID uint, seatNames []string, reason string, staffID uint) (*types.SeatBlockResponse, error) {
+	return s.changeScreenSeatBlocks(ctx, screenID, seatNames, true, reason, staffID)
+}
+
+// UnblockScreenSeats unblocks the named seats on every upcoming show of a screen, skipping seats that are not blocked
+func (s *seatService) UnblockScreenSeats(ctx context.Context, screenID uint, seatNames []string, reason string, staffID uint) (*types.SeatBlockResponse, error) {
+	return s.changeScreenSeatBlocks(ctx, screenID, seatNames, false, reason, staffID)
+}
+
+// changeShowSeatBlocks blocks or unblocks the given seats of a show in one transaction
+func (s *seatService) changeShowSeatBlocks(ctx context.Context, showID uint, seatIDs []uint, block bool, reason string, staffID uint) (*types.SeatBlockResponse, error) {
+	// Lock rows in ascending ID order, like seat locks, so the two cannot deadlock
+	sortedIDs := make([]uint, len(seatIDs))
+	copy(sortedIDs, seatIDs)
+	sort.Slice(sortedIDs, func(i, j int) bool { return sortedIDs[i] < sortedIDs[j] })
+
+	var changed []model.ShowSeat
+	err := s.withTransaction(ctx, func(tx model.DataStore) error {
+		if _, err := tx.GetShowByID(ctx, showID); err != nil {
+			return errors.NewHTTPError(http.StatusNotFound, "show not found")
+		}
+
+		seats := make([]model.ShowSeat, 0, len(sortedIDs))
+		for _, seatID := range sortedIDs {
+			seat, err := tx.GetSeatByIDForUpdate(ctx, seatID)
+			if err != nil {
+				return errors.NewHTTPError(http.StatusNotFound, fmt.Sprintf("seat %d not found", seatID))
+			}
+			if seat.ShowID != showID {
+				return errors.NewHTTPError(http.StatusBadRequest,
+					fmt.Sprintf("seat %d does not belong to this show", seatID))
+			}
+			seats = append(seats, *seat)
+		}
+
+		var skipped []types.SkippedSeat
+		var err error
+		changed, skipped, err = s.applySeatBlocks(ctx, tx, seats, block, reason, staffID)
+		if err != nil {
+			return err
+		}
+		if len(skipped) > 0 {
+			return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable,
+				fmt.Sprintf("seat %s %s", skipped[0].SeatName, skipped[0].Reason))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishSeatBlocks(changed, block)
+	return seatBlockResponse(changed, nil, block), nil
+}
+
+// changeScreenSeatBlocks blocks or unblocks seats by name across the screen's upcoming shows in one transaction
+func (s *seatService) changeScreenSeatBlocks(ctx context.Context, screenID uint, seatNames []string, block bool, reason string, staffID uint) (*types.SeatBlockResponse, error) {
+	var changed []model.ShowSeat
+	var skipped []types.SkippedSeat
+	err := s.withTransaction(ctx, func(tx model.DataStore) error {
+		seats, err := tx.GetUpcomingSeatsByScreenForUpdate(ctx, screenID, seatNames, time.Now())
+		if err != nil {
+			return err
+		}
+		if len(seats) == 0 {
+			return errors.NewHTTPError(http.StatusNotFound, "no upcoming show on this screen has those seats")
+		}
+
+		changed, skipped, err = s.applySeatBlocks(ctx, tx, seats, block, reason, staffID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishSeatBlocks(changed, block)
+	return seatBlockResponse(changed, skipped, block), nil
+}
+
+// applySeatBlocks blocks or unblocks seats already locked FOR UPDATE by tx, returning the seats it changed
+// and the ones it had to leave alone
+func (s *seatService) applySeatBlocks(ctx context.Context, tx model.DataStore, seats []model.ShowSeat, block bool, reason string, staffID uint) ([]model.ShowSeat, []types.SkippedSeat, error) {
+	now := time.Now()
+	lockDuration := config.GetSeatLockDuration()
+	actor := seatActor{userID: staffID, isStaff: true}
+
+	changed := []model.ShowSeat{}
+	skipped := []types.SkippedSeat{}
+	for i := range seats {
+		seat := &seats[i]
+
+		var transition constants.SeatTransition
+		var updates map[string]interface{}
+		if block {
+			skip, err := s.seatBlockConflict(ctx, tx, seat, now, lockDuration)
+			if err != nil {
+				return nil, nil, err
+			}
+			if skip != "" {
+				skipped = append(skipped, skippedSeat(seat, skip))
+				continue
+			}
+			transition = constants.SeatTransitionBlock
+			updates = map[string]interface{}{
+				"status":       string(constants.SeatStatusBlocked),
+				"block_reason": reason,
+				"locked_at":    nil,
+				"user_id":      nil,
+				"hold_id":      nil,
+			}
+		} else {
+			if seat.Status != string(constants.SeatStatusBlocked) {
+				skipped = append(skipped, skippedSeat(seat, "is not blocked"))
+				continue
+			}
+			transition = constants.SeatTransitionUnblock
+			updates = map[string]interface{}{
+				"status":       string(constants.SeatStatusAvailable),
+				"block_reason": nil,
+			}
+		}
+
+		if err := tx.UpdateSeat(ctx, seat.ID, updates, newSeatEvent(seat, transition, updates, actor, reason)); err != nil {
+			return nil, nil, fmt.Errorf("failed to update seat: %w", err)
+		}
+		changed = append(changed, *seat)
+	}
+	return changed, skipped, nil
+}
+
+// seatBlockConflict explains why a seat cannot be blocked right now, or returns "" if it can.
+// Blocking an already blocked seat only replaces its reason.
+func (s *seatService) seatBlockConflict(ctx context.Context, tx model.DataStore, seat *model.ShowSeat, now time.Time, lockDuration time.Duration) (string, error) {
+	switch {
+	case seat.Status == string(constants.SeatStatusBlocked):
+		return "", nil
+	case seat.Status == string(constants.SeatStatusSold):
+		return "is sold; cancel its booking first", nil
+	case checkSeatLockable(seat, now, lockDuration) != nil:
+		return "is held by a customer", nil
+	}
+
+	// Holds kept outside the row (memory backend) are only visible through the lock manager
+	locks, err := s.locks.Inspect(ctx, tx, coretypes.SeatLockFilter{SeatID: seat.ID})
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect seat lock: %w", err)
+	}
+	if len(locks) > 0 {
+		return "is held by a customer", nil
+	}
+	return "", nil
+}
+
+// publishSeatBlocks announces committed blocks and unblocks to seat map subscribers, and unblocked seats to the waitlist
+func (s *seatService) publishSeatBlocks(seats []model.ShowSeat, block bool) {
+	if block {
+		publishSeatEvents(s.events, constants.SeatEventBlocked, seats, nil, nil)
+		return
+	}
+	publishSeatEvents(s.events, constants.SeatEventUnblocked, seats, nil, nil)
+	notifySeatsFreed(s.waitlist, seats)
+}
+
+// seatBlockResponse summarises a block or unblock
+func seatBlockResponse(changed []model.ShowSeat, skipped []types.SkippedSeat, block bool) *types.SeatBlockResponse {
+	res := &types.SeatBlockResponse{
+		SeatIDs: make([]uint, 0, len(changed)),
+		ShowIDs: []uint{},
+		Skipped: skipped,
+	}
+	shows := map[uint]bool{}
+	for _, seat := range changed {
+		res.SeatIDs = append(res.SeatIDs, seat.ID)
+		if !shows[seat.ShowID] {
+			shows[seat.ShowID] = true
+			res.ShowIDs = append(res.ShowIDs, seat.ShowID)
+		}
+	}
+
+	verb := "Unblocked"
+	if block {
+		verb = "Blocked"
+	}
+	res.Message = fmt.Sprintf("%s %d seats across %d shows", verb, len(res.SeatIDs), len(res.ShowIDs))
+	return res
+}
+
+// skippedSeat records a seat left unchanged by a block or unblock
+func skippedSeat(seat *model.ShowSeat, reason string) types.SkippedSeat {
+	return types.SkippedSeat{
+		SeatID:   seat.ID,
+		ShowID:   seat.ShowID,
+		SeatName: seat.SeatName,
+		Reason:   reason,
+	}
+}
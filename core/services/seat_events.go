@@ -0,0 +1,40 @@
+package services
+
+import (
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/events"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+)
+
+// publishSeatEvents announces a committed status change of the given seats
+func publishSeatEvents(hub *events.Hub, eventType constants.SeatEventType, seats []model.ShowSeat, holdID *uint, expiresAt *time.Time) {
+	now := time.Now()
+	for _, seat := range seats {
+		hub.Publish(events.SeatEvent{
+			Type:       string(eventType),
+			ShowID:     seat.ShowID,
+			SeatID:     seat.ID,
+			SeatName:   seat.SeatName,
+			HoldID:     holdID,
+			ExpiresAt:  expiresAt,
+			OccurredAt: now,
+		})
+	}
+}
+
+// notifySeatsFreed tells the waitlist which shows have seats back on sale after a committed change
+func notifySeatsFreed(listener coretypes.SeatAvailabilityListener, seats []model.ShowSeat) {
+	if listener == nil {
+		return
+	}
+	notified := make(map[uint]bool)
+	for _, seat := range seats {
+		if !notified[seat.ShowID] {
+			notified[seat.ShowID] = true
+			listener.SeatsFreed(seat.ShowID)
+		}
+	}
+}
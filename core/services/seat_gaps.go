@@ -0,0 +1,59 @@
+package services
+
+import (
+	"time"
+
+	"movie-booking/core/model"
+)
+
+// findStrandedSeat returns a free seat that the selection would leave isolated between the
+// selection and a taken seat, an aisle or the end of the row; nil means the selection is fine.
+// Rows without seat numbers have no known adjacency and are never checked.
+func findStrandedSeat(seats []model.ShowSeat, selected map[uint]bool, now time.Time, lockDuration time.Duration) *model.ShowSeat {
+	for _, row := range groupSeatsByRow(seats) {
+		if !isRowNumbered(row) {
+			continue
+		}
+
+		taken := make([]bool, len(row.seats))
+		for i := range row.seats {
+			taken[i] = selected[row.seats[i].ID] || !isSeatFree(&row.seats[i], now, lockDuration)
+		}
+
+		// A neighbour is "closed" when it is taken or there is no seat next to it (aisle or row end)
+		closedLeft := func(i int) bool {
+			return i == 0 || row.seats[i-1].SeatNumber != row.seats[i].SeatNumber-1 || taken[i-1]
+		}
+		closedRight := func(i int) bool {
+			return i == len(row.seats)-1 || row.seats[i+1].SeatNumber != row.seats[i].SeatNumber+1 || taken[i+1]
+		}
+		nextToSelection := func(i int) bool {
+			left := i > 0 && row.seats[i-1].SeatNumber == row.seats[i].SeatNumber-1 && selected[row.seats[i-1].ID]
+			right := i < len(row.seats)-1 && row.seats[i+1].SeatNumber == row.seats[i].SeatNumber+1 && selected[row.seats[i+1].ID]
+			return left || right
+		}
+
+		for i := range row.seats {
+			if taken[i] || !nextToSelection(i) {
+				continue
+			}
+			if closedLeft(i) && closedRight(i) {
+				return &row.seats[i]
+			}
+		}
+	}
+	return nil
+}
+
+// isRowNumbered reports whether every seat of the row has a position, so neighbours can be told apart
+func isRowNumbered(row seatRow) bool {
+	if row.label == "" {
+		return false
+	}
+	for _, seat := range row.seats {
+		if seat.SeatNumber <= 0 {
+			return false
+		}
+	}
+	return true
+}
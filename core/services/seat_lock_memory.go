@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+)
+
+// memorySeatHold is a hold kept by the in-memory manager
+type memorySeatHold struct {
+	lock       coretypes.SeatLock
+	extensions int
+	createdAt  time.Time
+}
+
+// memorySeatLockManager keeps holds in process memory. It suits a single API node and tests:
+// holds are lost on restart, are not shared between replicas, and are not undone if the
+// caller's transaction rolls back (they simply expire).
+type memorySeatLockManager struct {
+	mu       sync.Mutex
+	nextID   uint
+	holds    map[uint]*memorySeatHold
+	seats    map[uint]uint   // seat ID -> hold ID
+	versions map[uint]uint64 // show ID -> number of hold changes
+}
+
+// NewMemorySeatLockManager creates a seat lock manager that is safe for concurrent use within one process
+func NewMemorySeatLockManager() coretypes.SeatLockManager {
+	return &memorySeatLockManager{
+		holds:    make(map[uint]*memorySeatHold),
+		seats:    make(map[uint]uint),
+		versions: make(map[uint]uint64),
+	}
+}
+
+// UsesTransaction is true so callers keep serializing a user's requests on the user row
+func (m *memorySeatLockManager) UsesTransaction() bool {
+	return true
+}
+
+// Acquire validates the seats against the store and the held set, then records a hold.
+// The seat rows are read FOR UPDATE in the caller's transaction, before taking the mutex so it is never held
+// across a database call. A booking keeps its seat rows locked from before it drops the hold until the SOLD
+// write commits, so this read waits for the sale instead of seeing the seat AVAILABLE with no hold.
+func (m *memorySeatLockManager) Acquire(ctx context.Context, tx model.DataStore, showID, userID uint, seatIDs []uint) (*coretypes.SeatLock, error) {
+	byID := make(map[uint]model.ShowSeat, len(seatIDs))
+	for _, seatID := range seatIDs {
+		seat, err := tx.GetSeatByIDForUpdate(ctx, seatID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seat: %w", err)
+		}
+		if seat.ShowID == showID {
+			byID[seat.ID] = *seat
+		}
+	}
+
+	lockDuration := config.GetSeatLockDuration()
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purgeExpired(now)
+
+	seats := make([]model.ShowSeat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		seat, ok := byID[seatID]
+		if !ok {
+			return nil, errors.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("seat %d does not belong to this show", seatID))
+		}
+		if _, held := m.seats[seatID]; held {
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable,
+				fmt.Sprintf("seat %s is already locked", seat.SeatName))
+		}
+		if err := checkSeatLockable(&seat, now, lockDuration); err != nil {
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+
+	m.nextID++
+	holdID := m.nextID
+	for i := range seats {
+		seats[i].Status = string(constants.SeatStatusLocked)
+		seats[i].LockedAt = &now
+		seats[i].UserID = &userID
+		seats[i].HoldID = &holdID
+		m.seats[seats[i].ID] = holdID
+	}
+	hold := &memorySeatHold{
+		lock: coretypes.SeatLock{
+			HoldID:    holdID,
+			ShowID:    showID,
+			UserID:    userID,
+			Seats:     seats,
+			LockedAt:  now,
+			ExpiresAt: now.Add(lockDuration),
+		},
+		createdAt: now,
+	}
+	m.holds[holdID] = hold
+	m.versions[showID]++
+
+	return copySeatLock(&hold.lock), nil
+}
+
+// Renew extends an unexpired hold under the same limits as the row-lock manager
+func (m *memorySeatLockManager) Renew(ctx context.Context, tx model.DataStore, holdID, userID uint) (*coretypes.SeatLock, error) {
+	lockDuration := config.GetSeatLockDuration()
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hold, ok := m.holds[holdID]
+	if !ok {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "hold not found")
+	}
+	if hold.lock.UserID != userID {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+			"only the holder can renew this hold")
+	}
+	if hold.extensions >= config.GetSeatHoldMaxExtensions() {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldLimit,
+			"hold has reached the maximum number of extensions")
+	}
+	if now.After(hold.lock.ExpiresAt) {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldExpired,
+			"hold has already lapsed")
+	}
+
+	lockedAt, err := renewedLockedAt(hold.createdAt, hold.lock.LockedAt, now, lockDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	hold.extensions++
+	hold.lock.LockedAt = lockedAt
+	hold.lock.ExpiresAt = lockedAt.Add(lockDuration)
+	for i := range hold.lock.Seats {
+		hold.lock.Seats[i].LockedAt = &lockedAt
+	}
+	m.versions[hold.lock.ShowID]++
+
+	return copySeatLock(&hold.lock), nil
+}
+
+// Release drops a whole hold or individual held seats
+func (m *memorySeatLockManager) Release(ctx context.Context, tx model.DataStore, req coretypes.SeatLockRelease) ([]model.ShowSeat, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if req.HoldID != 0 {
+		hold, ok := m.holds[req.HoldID]
+		if !ok {
+			return nil, errors.NewHTTPError(http.StatusNotFound, "hold not found")
+		}
+		if hold.lock.UserID != req.UserID && !req.IsStaff {
+			return nil, errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+				"only the holder can release this hold")
+		}
+		if now.After(hold.lock.ExpiresAt) {
+			m.deleteHold(hold)
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatNotLocked,
+				"hold has no locked seats")
+		}
+		m.deleteHold(hold)
+		return copySeatLock(&hold.lock).Seats, nil
+	}
+
+	// Validate every seat before dropping any, so a failed release changes nothing
+	holds := make([]*memorySeatHold, len(req.SeatIDs))
+	for i, seatID := range req.SeatIDs {
+		holdID, ok := m.seats[seatID]
+		if !ok || now.After(m.holds[holdID].lock.ExpiresAt) {
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatNotLocked,
+				fmt.Sprintf("seat %d is not locked", seatID))
+		}
+		hold := m.holds[holdID]
+		if hold.lock.UserID != req.UserID && !req.IsStaff {
+			return nil, errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+				fmt.Sprintf("seat %d is locked by another user", seatID))
+		}
+		holds[i] = hold
+	}
+
+	released := make([]model.ShowSeat, 0, len(req.SeatIDs))
+	for i, seatID := range req.SeatIDs {
+		hold := holds[i]
+		remaining := hold.lock.Seats[:0]
+		for _, seat := range hold.lock.Seats {
+			if seat.ID == seatID {
+				released = append(released, seat)
+				continue
+			}
+			remaining = append(remaining, seat)
+		}
+		hold.lock.Seats = remaining
+		delete(m.seats, seatID)
+		m.versions[hold.lock.ShowID]++
+		if len(hold.lock.Seats) == 0 {
+			delete(m.holds, hold.lock.HoldID)
+		}
+	}
+	return released, nil
+}
+
+// Inspect returns copies of the unexpired holds matching filter
+func (m *memorySeatLockManager) Inspect(ctx context.Context, tx model.DataStore, filter coretypes.SeatLockFilter) ([]coretypes.SeatLock, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	locks := []coretypes.SeatLock{}
+	for _, hold := range m.holds {
+		if now.After(hold.lock.ExpiresAt) {
+			continue
+		}
+		lock := copySeatLock(&hold.lock)
+		matching := lock.Seats[:0]
+		for _, seat := range lock.Seats {
+			if seatLockMatches(filter, lock.HoldID, lock.ShowID, lock.UserID, seat.ID) {
+				matching = append(matching, seat)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+		lock.Seats = matching
+		locks = append(locks, *lock)
+	}
+
+	sort.Slice(locks, func(i, j int) bool { return locks[i].HoldID < locks[j].HoldID })
+	return locks, nil
+}
+
+// Version purges lapsed holds, so an expiry counts as a change, and returns the show's change count
+func (m *memorySeatLockManager) Version(showID uint) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purgeExpired(time.Now())
+	return m.versions[showID]
+}
+
+// purgeExpired forgets lapsed holds; callers must hold m.mu
+func (m *memorySeatLockManager) purgeExpired(now time.Time) {
+	for _, hold := range m.holds {
+		if now.After(hold.lock.ExpiresAt) {
+			m.deleteHold(hold)
+		}
+	}
+}
+
+// deleteHold forgets a hold and its seats; callers must hold m.mu
+func (m *memorySeatLockManager) deleteHold(hold *memorySeatHold) {
+	for _, seat := range hold.lock.Seats {
+		delete(m.seats, seat.ID)
+	}
+	delete(m.holds, hold.lock.HoldID)
+	m.versions[hold.lock.ShowID]++
+}
+
+// copySeatLock returns a copy that callers may keep after the mutex is released
+func copySeatLock(lock *coretypes.SeatLock) *coretypes.SeatLock {
+	copied := *lock
+	copied.Seats = make([]model.ShowSeat, len(lock.Seats))
+	copy(copied.Seats, lock.Seats)
+	return &copied
+}
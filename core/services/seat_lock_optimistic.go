@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// optimisticLockAttempts bounds how often a compare-and-set is retried after another writer bumps the seat version
+const optimisticLockAttempts = 3
+
+// errSeatLeftHold marks a seat that no longer belongs to the hold being released
+var errSeatLeftHold = fmt.Errorf("seat is no longer in this hold")
+
+// optimisticSeatLockManager keeps holds on the show_seats rows like the row-lock manager, but never
+// takes FOR UPDATE locks: every write is a conditional UPDATE on the seat's version, committed on its own.
+type optimisticSeatLockManager struct {
+	rows rowSeatLockManager
+}
+
+// NewOptimisticSeatLockManager creates a seat lock manager that uses versioned compare-and-set updates
+func NewOptimisticSeatLockManager() coretypes.SeatLockManager {
+	return &optimisticSeatLockManager{}
+}
+
+// UsesTransaction is false: no connection waits on a hot row while a hold is taken
+func (m *optimisticSeatLockManager) UsesTransaction() bool {
+	return false
+}
+
+// Acquire validates every seat, records a hold and claims the seats one conditional UPDATE at a time,
+// undoing the claimed seats and the hold if a later seat is taken first
+func (m *optimisticSeatLockManager) Acquire(ctx context.Context, store model.DataStore, showID, userID uint, seatIDs []uint) (*coretypes.SeatLock, error) {
+	lockDuration := config.GetSeatLockDuration()
+	now := time.Now()
+
+	// Step 1: Read and validate every seat before writing anything
+	seats := make([]model.ShowSeat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		seat, err := store.GetSeatByID(ctx, seatID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seat: %w", err)
+		}
+		if seat.ShowID != showID {
+			return nil, errors.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("seat %d does not belong to this show", seatID))
+		}
+		if err := checkSeatLockable(seat, now, lockDuration); err != nil {
+			return nil, err
+		}
+		seats = append(seats, *seat)
+	}
+
+	// Step 2: Record the hold the seats will point at
+	hold, err := store.CreateSeatHold(ctx, &model.SeatHold{
+		ShowID: showID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create seat hold: %w", err)
+	}
+
+	// Step 3: Claim each seat with UPDATE ... WHERE id = ? AND version = ? AND (available or expired)
+	updates := map[string]interface{}{
+		"status":    string(constants.SeatStatusLocked),
+		"locked_at": now,
+		"user_id":   userID,
+		"hold_id":   hold.ID,
+	}
+	lockedBefore := now.Add(-lockDuration)
+	for i := range seats {
+		err := m.updateSeat(ctx, store, &seats[i],
+			func(seat *model.ShowSeat) error { return checkSeatLockable(seat, now, lockDuration) },
+			func(seat *model.ShowSeat) (bool, error) {
+				event := newSeatEvent(seat, constants.SeatTransitionLock, updates, seatActor{userID: userID}, lockReason(seat))
+				return store.LockSeatIfAvailable(ctx, seat.ID, seat.Version, lockedBefore, updates, event)
+			})
+		if err != nil {
+			m.undoAcquire(ctx, store, hold.ID, seats[:i], userID)
+			return nil, err
+		}
+		seats[i].Status = string(constants.SeatStatusLocked)
+		seats[i].LockedAt = &now
+		seats[i].UserID = &userID
+		seats[i].HoldID = &hold.ID
+	}
+
+	return &coretypes.SeatLock{
+		HoldID:    hold.ID,
+		ShowID:    showID,
+		UserID:    userID,
+		Seats:     seats,
+		LockedAt:  now,
+		ExpiresAt: now.Add(lockDuration),
+	}, nil
+}
+
+// Renew moves locked_at forward on every seat of an unexpired hold with conditional updates
+func (m *optimisticSeatLockManager) Renew(ctx context.Context, store model.DataStore, holdID, userID uint) (*coretypes.SeatLock, error) {
+	hold, err := store.GetSeatHoldByID(ctx, holdID)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "hold not found")
+	}
+	if hold.UserID != userID {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+			"only the holder can renew this hold")
+	}
+	if hold.Extensions >= config.GetSeatHoldMaxExtensions() {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldLimit,
+			"hold has reached the maximum number of extensions")
+	}
+
+	lockDuration := config.GetSeatLockDuration()
+	now := time.Now()
+	checkHeld := func(seat *model.ShowSeat) error {
+		if seat.Status != string(constants.SeatStatusLocked) || seat.HoldID == nil || *seat.HoldID != holdID ||
+			seat.LockedAt == nil || now.Sub(*seat.LockedAt) > lockDuration {
+			return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldExpired,
+				"hold has already lapsed")
+		}
+		return nil
+	}
+
+	if len(hold.Seats) == 0 {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldExpired,
+			"hold has already lapsed")
+	}
+	var currentLockedAt time.Time
+	for i := range hold.Seats {
+		if err := checkHeld(&hold.Seats[i]); err != nil {
+			return nil, err
+		}
+		currentLockedAt = *hold.Seats[i].LockedAt
+	}
+
+	lockedAt, err := renewedLockedAt(hold.CreatedAt, currentLockedAt, now, lockDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	seats := hold.Seats
+	updates := map[string]interface{}{"locked_at": lockedAt}
+	for i := range seats {
+		err := m.updateSeat(ctx, store, &seats[i], checkHeld, func(seat *model.ShowSeat) (bool, error) {
+			event := newSeatEvent(seat, constants.SeatTransitionRenew, updates, seatActor{userID: userID}, "hold renewed")
+			return store.UpdateSeatIfVersion(ctx, seat.ID, seat.Version, updates, event)
+		})
+		if err != nil {
+			return nil, err
+		}
+		seats[i].LockedAt = &lockedAt
+	}
+	// The total hold time is capped by renewedLockedAt, so a renewal racing this count cannot extend a hold further
+	if err := store.UpdateSeatHold(ctx, holdID, map[string]interface{}{"extensions": hold.Extensions + 1}); err != nil {
+		return nil, fmt.Errorf("failed to record hold extension: %w", err)
+	}
+
+	return &coretypes.SeatLock{
+		HoldID:    holdID,
+		ShowID:    hold.ShowID,
+		UserID:    hold.UserID,
+		Seats:     seats,
+		LockedAt:  lockedAt,
+		ExpiresAt: lockedAt.Add(lockDuration),
+	}, nil
+}
+
+// Release returns the requested seats to AVAILABLE with conditional updates
+func (m *optimisticSeatLockManager) Release(ctx context.Context, store model.DataStore, req coretypes.SeatLockRelease) ([]model.ShowSeat, error) {
+	if req.HoldID == 0 {
+		released := make([]model.ShowSeat, 0, len(req.SeatIDs))
+		for _, seatID := range req.SeatIDs {
+			seat, err := store.GetSeatByID(ctx, seatID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get seat: %w", err)
+			}
+			err = m.updateSeat(ctx, store, seat,
+				func(seat *model.ShowSeat) error { return checkSeatReleasable(seat, req.UserID, req.IsStaff) },
+				func(seat *model.ShowSeat) (bool, error) {
+					return store.UpdateSeatIfVersion(ctx, seat.ID, seat.Version, releasedSeatUpdates(), releasedSeatEvent(seat, req))
+				})
+			if err != nil {
+				return nil, err
+			}
+			released = append(released, *seat)
+		}
+		return released, nil
+	}
+
+	hold, err := store.GetSeatHoldByID(ctx, req.HoldID)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "hold not found")
+	}
+	if hold.UserID != req.UserID && !req.IsStaff {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+			"only the holder can release this hold")
+	}
+
+	checkInHold := func(seat *model.ShowSeat) error {
+		if seat.Status != string(constants.SeatStatusLocked) || seat.HoldID == nil || *seat.HoldID != req.HoldID {
+			return errSeatLeftHold
+		}
+		return nil
+	}
+	released := []model.ShowSeat{}
+	for i := range hold.Seats {
+		seat := &hold.Seats[i]
+		err := m.updateSeat(ctx, store, seat, checkInHold, func(seat *model.ShowSeat) (bool, error) {
+			return store.UpdateSeatIfVersion(ctx, seat.ID, seat.Version, releasedSeatUpdates(), releasedSeatEvent(seat, req))
+		})
+		if err == errSeatLeftHold {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		released = append(released, *seat)
+	}
+
+	if len(released) == 0 {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatNotLocked,
+			"hold has no locked seats")
+	}
+	return released, nil
+}
+
+// Inspect reads the held seat rows exactly as the row-lock manager does
+func (m *optimisticSeatLockManager) Inspect(ctx context.Context, store model.DataStore, filter coretypes.SeatLockFilter) ([]coretypes.SeatLock, error) {
+	return m.rows.Inspect(ctx, store, filter)
+}
+
+// Version is 0: optimistic locks are recorded in show_seats, whose versions already change with them
+func (m *optimisticSeatLockManager) Version(showID uint) uint64 {
+	return 0
+}
+
+// updateSeat runs check and then cas on the seat as last read, re-reading the seat and retrying when another writer
+// bumped the version first. On success seat carries the new version.
+func (m *optimisticSeatLockManager) updateSeat(ctx context.Context, store model.DataStore, seat *model.ShowSeat,
+	check func(seat *model.ShowSeat) error, cas func(seat *model.ShowSeat) (bool, error)) error {
+	for attempt := 1; ; attempt++ {
+		if err := check(seat); err != nil {
+			return err
+		}
+		updated, err := cas(seat)
+		if err != nil {
+			return err
+		}
+		if updated {
+			seat.Version++
+			return nil
+		}
+		if attempt == optimisticLockAttempts {
+			return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable,
+				fmt.Sprintf("seat %s is being changed by another request", seat.SeatName))
+		}
+
+		fresh, err := store.GetSeatByID(ctx, seat.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get seat: %w", err)
+		}
+		*seat = *fresh
+	}
+}
+
+// undoAcquire frees seats claimed by a failed Acquire and deletes its hold. A seat that changed since is
+// left alone; its lock lapses normally. The hold is kept while a seat may still point at it.
+func (m *optimisticSeatLockManager) undoAcquire(ctx context.Context, store model.DataStore, holdID uint, seats []model.ShowSeat, userID uint) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"holdID": holdID,
+		"userID": userID,
+	})
+
+	undone := true
+	for i := range seats {
+		event := newSeatEvent(&seats[i], constants.SeatTransitionRelease, releasedSeatUpdates(), seatActor{userID: userID},
+			"multi-seat lock failed")
+		ok, err := store.UpdateSeatIfVersion(ctx, seats[i].ID, seats[i].Version, releasedSeatUpdates(), event)
+		if err != nil {
+			logger.WithError(err).WithField("seatID", seats[i].ID).Error("Failed to undo seat lock of a failed multi-seat lock")
+			undone = false
+		} else if !ok {
+			logger.WithField("seatID", seats[i].ID).Warn("Seat changed before the lock of a failed multi-seat lock was undone")
+			undone = false
+		}
+	}
+	if !undone {
+		return
+	}
+	if err := store.DeleteSeatHold(ctx, holdID); err != nil {
+		logger.WithError(err).Error("Failed to delete hold of a failed multi-seat lock")
+	}
+}
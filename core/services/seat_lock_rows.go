@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+)
+
+// rowSeatLockManager keeps holds on the show_seats rows, serialized with SELECT ... FOR UPDATE
+type rowSeatLockManager struct{}
+
+// NewRowSeatLockManager creates a seat lock manager backed by MySQL row locks
+func NewRowSeatLockManager() coretypes.SeatLockManager {
+	return &rowSeatLockManager{}
+}
+
+// UsesTransaction is true: row locks only last as long as the caller's transaction
+func (m *rowSeatLockManager) UsesTransaction() bool {
+	return true
+}
+
+// Acquire locks the seat rows in the given order, validates them and records a hold
+func (m *rowSeatLockManager) Acquire(ctx context.Context, tx model.DataStore, showID, userID uint, seatIDs []uint) (*coretypes.SeatLock, error) {
+	lockDuration := config.GetSeatLockDuration()
+	now := time.Now()
+
+	seats := make([]model.ShowSeat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		seat, err := tx.GetSeatByIDForUpdate(ctx, seatID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seat: %w", err)
+		}
+		if seat.ShowID != showID {
+			return nil, errors.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("seat %d does not belong to this show", seatID))
+		}
+		if err := checkSeatLockable(seat, now, lockDuration); err != nil {
+			return nil, err
+		}
+		seats = append(seats, *seat)
+	}
+
+	hold, err := tx.CreateSeatHold(ctx, &model.SeatHold{
+		ShowID: showID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create seat hold: %w", err)
+	}
+
+	for i := range seats {
+		updates := map[string]interface{}{
+			"status":    string(constants.SeatStatusLocked),
+			"locked_at": now,
+			"user_id":   userID,
+			"hold_id":   hold.ID,
+		}
+		event := newSeatEvent(&seats[i], constants.SeatTransitionLock, updates, seatActor{userID: userID}, lockReason(&seats[i]))
+		if err := tx.UpdateSeat(ctx, seats[i].ID, updates, event); err != nil {
+			return nil, fmt.Errorf("failed to lock seat: %w", err)
+		}
+		seats[i].Status = string(constants.SeatStatusLocked)
+		seats[i].LockedAt = &now
+		seats[i].UserID = &userID
+		seats[i].HoldID = &hold.ID
+	}
+
+	return &coretypes.SeatLock{
+		HoldID:    hold.ID,
+		ShowID:    showID,
+		UserID:    userID,
+		Seats:     seats,
+		LockedAt:  now,
+		ExpiresAt: now.Add(lockDuration),
+	}, nil
+}
+
+// Renew moves locked_at forward on every seat of an unexpired hold
+func (m *rowSeatLockManager) Renew(ctx context.Context, tx model.DataStore, holdID, userID uint) (*coretypes.SeatLock, error) {
+	// Step 1: Lock the hold row so concurrent renewals see the same extension count
+	hold, err := tx.GetSeatHoldByIDForUpdate(ctx, holdID)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "hold not found")
+	}
+	if hold.UserID != userID {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+			"only the holder can renew this hold")
+	}
+	if hold.Extensions >= config.GetSeatHoldMaxExtensions() {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldLimit,
+			"hold has reached the maximum number of extensions")
+	}
+
+	// Step 2: Lock the seat rows and make sure every seat is still held and unexpired
+	lockDuration := config.GetSeatLockDuration()
+	now := time.Now()
+	if len(hold.Seats) == 0 {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldExpired,
+			"hold has already lapsed")
+	}
+	seats := make([]model.ShowSeat, 0, len(hold.Seats))
+	var currentLockedAt time.Time
+	for _, heldSeat := range hold.Seats {
+		seat, err := tx.GetSeatByIDForUpdate(ctx, heldSeat.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seat: %w", err)
+		}
+		if seat.Status != string(constants.SeatStatusLocked) || seat.HoldID == nil || *seat.HoldID != holdID ||
+			seat.LockedAt == nil || now.Sub(*seat.LockedAt) > lockDuration {
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldExpired,
+				"hold has already lapsed")
+		}
+		currentLockedAt = *seat.LockedAt
+		seats = append(seats, *seat)
+	}
+
+	// Step 3: Move locked_at forward, never past the maximum total hold time
+	lockedAt, err := renewedLockedAt(hold.CreatedAt, currentLockedAt, now, lockDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{"locked_at": lockedAt}
+	for i := range seats {
+		event := newSeatEvent(&seats[i], constants.SeatTransitionRenew, updates, seatActor{userID: userID}, "hold renewed")
+		if err := tx.UpdateSeat(ctx, seats[i].ID, updates, event); err != nil {
+			return nil, fmt.Errorf("failed to renew seat lock: %w", err)
+		}
+		seats[i].LockedAt = &lockedAt
+	}
+	if err := tx.UpdateSeatHold(ctx, holdID, map[string]interface{}{"extensions": hold.Extensions + 1}); err != nil {
+		return nil, fmt.Errorf("failed to record hold extension: %w", err)
+	}
+
+	return &coretypes.SeatLock{
+		HoldID:    holdID,
+		ShowID:    hold.ShowID,
+		UserID:    hold.UserID,
+		Seats:     seats,
+		LockedAt:  lockedAt,
+		ExpiresAt: lockedAt.Add(lockDuration),
+	}, nil
+}
+
+// Release returns the requested seats to AVAILABLE
+func (m *rowSeatLockManager) Release(ctx context.Context, tx model.DataStore, req coretypes.SeatLockRelease) ([]model.ShowSeat, error) {
+	if req.HoldID == 0 {
+		released := make([]model.ShowSeat, 0, len(req.SeatIDs))
+		for _, seatID := range req.SeatIDs {
+			seat, err := tx.GetSeatByIDForUpdate(ctx, seatID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get seat: %w", err)
+			}
+			if err := checkSeatReleasable(seat, req.UserID, req.IsStaff); err != nil {
+				return nil, err
+			}
+			if err := tx.UpdateSeat(ctx, seatID, releasedSeatUpdates(), releasedSeatEvent(seat, req)); err != nil {
+				return nil, fmt.Errorf("failed to release seat: %w", err)
+			}
+			released = append(released, *seat)
+		}
+		return released, nil
+	}
+
+	hold, err := tx.GetSeatHoldByID(ctx, req.HoldID)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "hold not found")
+	}
+	if hold.UserID != req.UserID && !req.IsStaff {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+			"only the holder can release this hold")
+	}
+
+	// Seats are preloaded in ID order, so row locks are taken in the same order as Acquire
+	released := []model.ShowSeat{}
+	for _, heldSeat := range hold.Seats {
+		seat, err := tx.GetSeatByIDForUpdate(ctx, heldSeat.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seat: %w", err)
+		}
+		if seat.Status != string(constants.SeatStatusLocked) || seat.HoldID == nil || *seat.HoldID != req.HoldID {
+			continue
+		}
+		if err := tx.UpdateSeat(ctx, seat.ID, releasedSeatUpdates(), releasedSeatEvent(seat, req)); err != nil {
+			return nil, fmt.Errorf("failed to release seat: %w", err)
+		}
+		released = append(released, *seat)
+	}
+
+	if len(released) == 0 {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatNotLocked,
+			"hold has no locked seats")
+	}
+	return released, nil
+}
+
+// Inspect reads the held seat rows matching filter; a seat filter locks that row for the caller's transaction
+func (m *rowSeatLockManager) Inspect(ctx context.Context, tx model.DataStore, filter coretypes.SeatLockFilter) ([]coretypes.SeatLock, error) {
+	lockDuration := config.GetSeatLockDuration()
+	now := time.Now()
+
+	var seats []model.ShowSeat
+	switch {
+	case filter.SeatID != 0:
+		seat, err := tx.GetSeatByIDForUpdate(ctx, filter.SeatID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seat: %w", err)
+		}
+		seats = []model.ShowSeat{*seat}
+	case filter.HoldID != 0:
+		hold, err := tx.GetSeatHoldByID(ctx, filter.HoldID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seat hold: %w", err)
+		}
+		seats = hold.Seats
+	case filter.UserID != 0:
+		var err error
+		if seats, err = tx.GetLockedSeatsByUser(ctx, filter.UserID, now.Add(-lockDuration)); err != nil {
+			return nil, err
+		}
+	case filter.ShowID != 0:
+		var err error
+		if seats, err = tx.GetSeatsByShowID(ctx, filter.ShowID); err != nil {
+			return nil, fmt.Errorf("failed to get seats: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("seat lock filter is empty")
+	}
+
+	return groupSeatLocks(seats, filter, now, lockDuration), nil
+}
+
+// Version is 0: row locks are recorded in show_seats, whose versions already change with them
+func (m *rowSeatLockManager) Version(showID uint) uint64 {
+	return 0
+}
+
+// renewedLockedAt returns the new lock start for a renewal, never past the maximum total hold time
+func renewedLockedAt(holdCreatedAt, currentLockedAt, now time.Time, lockDuration time.Duration) (time.Time, error) {
+	lockedAt := now
+	if maxLockedAt := holdCreatedAt.Add(config.GetSeatHoldMaxDuration() - lockDuration); lockedAt.After(maxLockedAt) {
+		lockedAt = maxLockedAt
+	}
+	if !lockedAt.After(currentLockedAt) {
+		return time.Time{}, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldLimit,
+			"hold has reached the maximum hold time")
+	}
+	return lockedAt, nil
+}
+
+// checkSeatReleasable ensures the seat is locked and the caller is its holder or staff
+func checkSeatReleasable(seat *model.ShowSeat, userID uint, isStaff bool) error {
+	if seat.Status != string(constants.SeatStatusLocked) {
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatNotLocked,
+			fmt.Sprintf("seat %s is not locked", seat.SeatName))
+	}
+	if !isStaff && (seat.UserID == nil || *seat.UserID != userID) {
+		return errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeNotSeatHolder,
+			fmt.Sprintf("seat %s is locked by another user", seat.SeatName))
+	}
+	return nil
+}
+
+// releasedSeatUpdates returns the column values of a seat returned to AVAILABLE
+func releasedSeatUpdates() map[string]interface{} {
+	return map[string]interface{}{
+		"status":    string(constants.SeatStatusAvailable),
+		"locked_at": nil,
+		"user_id":   nil,
+		"hold_id":   nil,
+	}
+}
+
+// releasedSeatEvent records a release made on behalf of req
+func releasedSeatEvent(seat *model.ShowSeat, req coretypes.SeatLockRelease) *model.SeatEvent {
+	actor := seatActor{userID: req.UserID, isStaff: req.IsStaff}
+	return newSeatEvent(seat, constants.SeatTransitionRelease, releasedSeatUpdates(), actor, releaseReason(seat, actor, req.Reason))
+}
+
+// lockReason notes in the seat history when a lock takes over one that had expired
+func lockReason(seat *model.ShowSeat) string {
+	if seat.Status == string(constants.SeatStatusLocked) {
+		return "previous lock expired"
+	}
+	return ""
+}
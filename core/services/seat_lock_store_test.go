@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/model"
+)
+
+// fakeSeatData is the shared state behind every fakeSeatStore of one test
+type fakeSeatData struct {
+	mu         sync.Mutex
+	seats      map[uint]*model.ShowSeat
+	holds      map[uint]*model.SeatHold
+	nextHoldID uint
+	rowLocks   map[string]*sync.Mutex
+}
+
+// fakeSeatStore implements the seat and seat hold parts of model.DataStore in memory. A store from Begin
+// keeps the rows it reads FOR UPDATE locked until Commit or Rollback, like InnoDB; writes are applied
+// immediately and are not undone by Rollback. Any other DataStore method panics.
+type fakeSeatStore struct {
+	model.DataStore
+	data   *fakeSeatData
+	inTx   bool
+	locked []*sync.Mutex
+}
+
+// newFakeSeatStore creates a store holding seatCount AVAILABLE seats of showID, numbered from 1
+func newFakeSeatStore(showID uint, seatCount int) *fakeSeatStore {
+	data := &fakeSeatData{
+		seats:    make(map[uint]*model.ShowSeat),
+		holds:    make(map[uint]*model.SeatHold),
+		rowLocks: make(map[string]*sync.Mutex),
+	}
+	for i := 1; i <= seatCount; i++ {
+		data.seats[uint(i)] = &model.ShowSeat{
+			ID:         uint(i),
+			ShowID:     showID,
+			SeatName:   fmt.Sprintf("A%d", i),
+			RowLabel:   "A",
+			SeatNumber: i,
+			Category:   "STANDARD",
+			Status:     string(constants.SeatStatusAvailable),
+		}
+	}
+	return &fakeSeatStore{data: data}
+}
+
+func (s *fakeSeatStore) Begin(ctx context.Context) (model.DataStore, error) {
+	return &fakeSeatStore{data: s.data, inTx: true}, nil
+}
+
+func (s *fakeSeatStore) Commit(ctx context.Context) error {
+	s.unlockRows()
+	return nil
+}
+
+func (s *fakeSeatStore) Rollback(ctx context.Context) error {
+	s.unlockRows()
+	return nil
+}
+
+func (s *fakeSeatStore) InTransactionMode(ctx context.Context) bool {
+	return s.inTx
+}
+
+// lockRow waits for the named row; outside a transaction the lock is released as soon as it is taken
+func (s *fakeSeatStore) lockRow(key string) {
+	s.data.mu.Lock()
+	rowLock, ok := s.data.rowLocks[key]
+	if !ok {
+		rowLock = &sync.Mutex{}
+		s.data.rowLocks[key] = rowLock
+	}
+	s.data.mu.Unlock()
+
+	rowLock.Lock()
+	if !s.inTx {
+		rowLock.Unlock()
+		return
+	}
+	s.locked = append(s.locked, rowLock)
+}
+
+func (s *fakeSeatStore) unlockRows() {
+	for i := len(s.locked) - 1; i >= 0; i-- {
+		s.locked[i].Unlock()
+	}
+	s.locked = nil
+}
+
+func (s *fakeSeatStore) GetSeatsByShowID(ctx context.Context, showID uint) ([]model.ShowSeat, error) {
+	return s.findSeats(func(seat *model.ShowSeat) bool { return seat.ShowID == showID }), nil
+}
+
+func (s *fakeSeatStore) GetSeatByID(ctx context.Context, id uint) (*model.ShowSeat, error) {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	seat, ok := s.data.seats[id]
+	if !ok {
+		return nil, fmt.Errorf("seat not found")
+	}
+	copied := *seat
+	return &copied, nil
+}
+
+func (s *fakeSeatStore) GetSeatByIDForUpdate(ctx context.Context, id uint) (*model.ShowSeat, error) {
+	s.lockRow(fmt.Sprintf("seat:%d", id))
+	return s.GetSeatByID(ctx, id)
+}
+
+func (s *fakeSeatStore) UpdateSeat(ctx context.Context, id uint, updates map[string]interface{}, event *model.SeatEvent) error {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	seat, ok := s.data.seats[id]
+	if !ok {
+		return fmt.Errorf("seat not found or no changes made")
+	}
+	applyFakeSeatUpdates(seat, updates)
+	return nil
+}
+
+func (s *fakeSeatStore) UpdateSeatIfVersion(ctx context.Context, id uint, version uint, updates map[string]interface{}, event *model.SeatEvent) (bool, error) {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	seat, ok := s.data.seats[id]
+	if !ok || seat.Version != version {
+		return false, nil
+	}
+	applyFakeSeatUpdates(seat, updates)
+	return true, nil
+}
+
+func (s *fakeSeatStore) LockSeatIfAvailable(ctx context.Context, id uint, version uint, lockedBefore time.Time, updates map[string]interface{}, event *model.SeatEvent) (bool, error) {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	seat, ok := s.data.seats[id]
+	if !ok || seat.Version != version {
+		return false, nil
+	}
+	expired := seat.Status == string(constants.SeatStatusLocked) && seat.LockedAt != nil && seat.LockedAt.Before(lockedBefore)
+	if seat.Status != string(constants.SeatStatusAvailable) && !expired {
+		return false, nil
+	}
+	applyFakeSeatUpdates(seat, updates)
+	return true, nil
+}
+
+func (s *fakeSeatStore) GetLockedSeatsByUser(ctx context.Context, userID uint, lockedAfter time.Time) ([]model.ShowSeat, error) {
+	return s.findSeats(func(seat *model.ShowSeat) bool {
+		return seat.UserID != nil && *seat.UserID == userID && seat.Status == string(constants.SeatStatusLocked) &&
+			seat.LockedAt != nil && !seat.LockedAt.Before(lockedAfter)
+	}), nil
+}
+
+func (s *fakeSeatStore) CreateSeatHold(ctx context.Context, hold *model.SeatHold) (*model.SeatHold, error) {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	s.data.nextHoldID++
+	hold.ID = s.data.nextHoldID
+	hold.CreatedAt = time.Now()
+	stored := *hold
+	s.data.holds[hold.ID] = &stored
+	return hold, nil
+}
+
+func (s *fakeSeatStore) GetSeatHoldByID(ctx context.Context, id uint) (*model.SeatHold, error) {
+	s.data.mu.Lock()
+	hold, ok := s.data.holds[id]
+	s.data.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("seat hold not found")
+	}
+	copied := *hold
+	copied.Seats = s.findSeats(func(seat *model.ShowSeat) bool { return seat.HoldID != nil && *seat.HoldID == id })
+	return &copied, nil
+}
+
+func (s *fakeSeatStore) GetSeatHoldByIDForUpdate(ctx context.Context, id uint) (*model.SeatHold, error) {
+	s.lockRow(fmt.Sprintf("hold:%d", id))
+	return s.GetSeatHoldByID(ctx, id)
+}
+
+func (s *fakeSeatStore) UpdateSeatHold(ctx context.Context, id uint, updates map[string]interface{}) error {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	hold, ok := s.data.holds[id]
+	if !ok {
+		return fmt.Errorf("seat hold not found or no changes made")
+	}
+	for column, value := range updates {
+		switch column {
+		case "extensions":
+			hold.Extensions = value.(int)
+		default:
+			panic(fmt.Sprintf("fake store cannot update seat_holds.%s", column))
+		}
+	}
+	return nil
+}
+
+func (s *fakeSeatStore) DeleteSeatHold(ctx context.Context, id uint) error {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	delete(s.data.holds, id)
+	for _, seat := range s.data.seats {
+		if seat.HoldID != nil && *seat.HoldID == id {
+			seat.HoldID = nil
+		}
+	}
+	return nil
+}
+
+// emptyHoldIDs lists the holds no seat points at
+func (s *fakeSeatStore) emptyHoldIDs() []uint {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	used := make(map[uint]bool)
+	for _, seat := range s.data.seats {
+		if seat.HoldID != nil {
+			used[*seat.HoldID] = true
+		}
+	}
+	empty := []uint{}
+	for id := range s.data.holds {
+		if !used[id] {
+			empty = append(empty, id)
+		}
+	}
+	return empty
+}
+
+// findSeats returns copies of the matching seats in ID order
+func (s *fakeSeatStore) findSeats(match func(seat *model.ShowSeat) bool) []model.ShowSeat {
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+	seats := []model.ShowSeat{}
+	for _, seat := range s.data.seats {
+		if match(seat) {
+			seats = append(seats, *seat)
+		}
+	}
+	sort.Slice(seats, func(i, j int) bool { return seats[i].ID < seats[j].ID })
+	return seats
+}
+
+// applyFakeSeatUpdates applies the column updates the seat lock managers write and bumps the version
+func applyFakeSeatUpdates(seat *model.ShowSeat, updates map[string]interface{}) {
+	for column, value := range updates {
+		switch column {
+		case "status":
+			seat.Status = value.(string)
+		case "locked_at":
+			seat.LockedAt = nil
+			if lockedAt, ok := value.(time.Time); ok {
+				seat.LockedAt = &lockedAt
+			}
+		case "user_id":
+			seat.UserID = nil
+			if userID, ok := value.(uint); ok {
+				seat.UserID = &userID
+			}
+		case "hold_id":
+			seat.HoldID = nil
+			if holdID, ok := value.(uint); ok {
+				seat.HoldID = &holdID
+			}
+		default:
+			panic(fmt.Sprintf("fake store cannot update show_seats.%s", column))
+		}
+	}
+	seat.Version++
+}
+
+func (s *fakeSeatStore) CountBookingsByUserAndShow(ctx context.Context, userID, showID uint) (int64, error) {
+	return 0, nil
+}
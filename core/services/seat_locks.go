@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+)
+
+// NewSeatLockManager creates the seat hold backend named by SEAT_LOCK_BACKEND
+func NewSeatLockManager(backend string) (coretypes.SeatLockManager, error) {
+	switch constants.SeatLockBackend(backend) {
+	case constants.SeatLockBackendMySQL:
+		return NewRowSeatLockManager(), nil
+	case constants.SeatLockBackendOptimistic:
+		return NewOptimisticSeatLockManager(), nil
+	case constants.SeatLockBackendMemory:
+		return NewMemorySeatLockManager(), nil
+	}
+	return nil, fmt.Errorf("unknown seat lock backend: %s", backend)
+}
+
+// seatLockManager returns the injected seat lock manager, defaulting to MySQL row locks
+func seatLockManager(clients *coretypes.Clients) coretypes.SeatLockManager {
+	if clients.SeatLocks != nil {
+		return clients.SeatLocks
+	}
+	return NewRowSeatLockManager()
+}
+
+// applySeatLocks overlays the active locks on seats read from the store; stale row locks read as AVAILABLE
+func applySeatLocks(seats []model.ShowSeat, locks []coretypes.SeatLock, now time.Time, lockDuration time.Duration) {
+	held := make(map[uint]*coretypes.SeatLock)
+	for i := range locks {
+		for _, seat := range locks[i].Seats {
+			held[seat.ID] = &locks[i]
+		}
+	}
+
+	for i := range seats {
+		if lock, ok := held[seats[i].ID]; ok {
+			lockedAt, userID, holdID := lock.LockedAt, lock.UserID, lock.HoldID
+			seats[i].Status = string(constants.SeatStatusLocked)
+			seats[i].LockedAt = &lockedAt
+			seats[i].UserID = &userID
+			seats[i].HoldID = &holdID
+			continue
+		}
+		// Lazy lock expiration: treat expired locks as AVAILABLE
+		if seats[i].Status == string(constants.SeatStatusLocked) && (seats[i].LockedAt == nil || now.Sub(*seats[i].LockedAt) > lockDuration) {
+			seats[i].Status = string(constants.SeatStatusAvailable)
+			seats[i].LockedAt = nil
+			seats[i].UserID = nil
+			seats[i].HoldID = nil
+		}
+	}
+}
+
+// groupSeatLocks builds the locks held through seat rows, keeping only unexpired seats that match filter
+func groupSeatLocks(seats []model.ShowSeat, filter coretypes.SeatLockFilter, now time.Time, lockDuration time.Duration) []coretypes.SeatLock {
+	locks := []coretypes.SeatLock{}
+	index := make(map[uint]int)
+	for _, seat := range seats {
+		if seat.Status != string(constants.SeatStatusLocked) || seat.LockedAt == nil || seat.UserID == nil ||
+			now.Sub(*seat.LockedAt) > lockDuration {
+			continue
+		}
+		var holdID uint
+		if seat.HoldID != nil {
+			holdID = *seat.HoldID
+		}
+		if !seatLockMatches(filter, holdID, seat.ShowID, *seat.UserID, seat.ID) {
+			continue
+		}
+
+		i, ok := index[holdID]
+		if !ok {
+			i = len(locks)
+			index[holdID] = i
+			locks = append(locks, coretypes.SeatLock{
+				HoldID:    holdID,
+				ShowID:    seat.ShowID,
+				UserID:    *seat.UserID,
+				LockedAt:  *seat.LockedAt,
+				ExpiresAt: seat.LockedAt.Add(lockDuration),
+			})
+		}
+		locks[i].Seats = append(locks[i].Seats, seat)
+	}
+	return locks
+}
+
+// seatLockMatches reports whether a held seat passes every non-zero field of filter
+func seatLockMatches(filter coretypes.SeatLockFilter, holdID, showID, userID, seatID uint) bool {
+	return (filter.HoldID == 0 || filter.HoldID == holdID) &&
+		(filter.ShowID == 0 || filter.ShowID == showID) &&
+		(filter.UserID == 0 || filter.UserID == userID) &&
+		(filter.SeatID == 0 || filter.SeatID == seatID)
+}
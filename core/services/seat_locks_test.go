@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+)
+
+const testShowID uint = 7
+
+// seatLockBackends lists every SeatLockManager; each test runs against all of them
+var seatLockBackends = []struct {
+	name string
+	new  func() coretypes.SeatLockManager
+}{
+	{"memory", NewMemorySeatLockManager},
+	{"mysql", NewRowSeatLockManager},
+	{"optimistic", NewOptimisticSeatLockManager},
+}
+
+// setupSeatLockConfig loads the default configuration with the given overrides
+func setupSeatLockConfig(t *testing.T, overrides map[string]string) {
+	t.Helper()
+	for key, value := range overrides {
+		t.Setenv(key, value)
+	}
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init: %v", err)
+	}
+}
+
+// withSeatLocks runs call inside a transaction when the manager needs one, committing only if call succeeds
+func withSeatLocks(m coretypes.SeatLockManager, store model.DataStore, call func(tx model.DataStore) error) error {
+	ctx := context.Background()
+	if !m.UsesTransaction() {
+		return call(store)
+	}
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := call(tx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func acquireSeats(m coretypes.SeatLockManager, store model.DataStore, userID uint, seatIDs ...uint) (*coretypes.SeatLock, error) {
+	var lock *coretypes.SeatLock
+	err := withSeatLocks(m, store, func(tx model.DataStore) error {
+		var err error
+		lock, err = m.Acquire(context.Background(), tx, testShowID, userID, seatIDs)
+		return err
+	})
+	return lock, err
+}
+
+func renewHold(m coretypes.SeatLockManager, store model.DataStore, holdID, userID uint) (*coretypes.SeatLock, error) {
+	var lock *coretypes.SeatLock
+	err := withSeatLocks(m, store, func(tx model.DataStore) error {
+		var err error
+		lock, err = m.Renew(context.Background(), tx, holdID, userID)
+		return err
+	})
+	return lock, err
+}
+
+func inspectShow(t *testing.T, m coretypes.SeatLockManager, store model.DataStore) []coretypes.SeatLock {
+	t.Helper()
+	var locks []coretypes.SeatLock
+	err := withSeatLocks(m, store, func(tx model.DataStore) error {
+		var err error
+		locks, err = m.Inspect(context.Background(), tx, coretypes.SeatLockFilter{ShowID: testShowID})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	return locks
+}
+
+// heldSeats maps every held seat of the show to the user holding it
+func heldSeats(t *testing.T, m coretypes.SeatLockManager, store model.DataStore) map[uint]uint {
+	t.Helper()
+	held := make(map[uint]uint)
+	for _, lock := range inspectShow(t, m, store) {
+		for _, seat := range lock.Seats {
+			if _, ok := held[seat.ID]; ok {
+				t.Fatalf("seat %d is in more than one hold", seat.ID)
+			}
+			held[seat.ID] = lock.UserID
+		}
+	}
+	return held
+}
+
+func expectErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	httpErr, ok := errors.IsHTTPError(err)
+	if !ok || httpErr.Code != code {
+		t.Fatalf("expected %s error, got %v", code, err)
+	}
+}
+
+func TestSeatLockManagerAcquireIsAllOrNothing(t *testing.T) {
+	setupSeatLockConfig(t, nil)
+	for _, backend := range seatLockBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			m, store := backend.new(), newFakeSeatStore(testShowID, 4)
+
+			if _, err := acquireSeats(m, store, 1, 3); err != nil {
+				t.Fatalf("Acquire: %v", err)
+			}
+			_, err := acquireSeats(m, store, 2, 1, 2, 3)
+			expectErrorCode(t, err, constants.ErrCodeSeatUnavailable)
+
+			held := heldSeats(t, m, store)
+			if len(held) != 1 || held[3] != 1 {
+				t.Fatalf("failed Acquire left seats held: %v", held)
+			}
+			if _, err := acquireSeats(m, store, 3, 1, 2); err != nil {
+				t.Fatalf("seats of a failed Acquire are not free: %v", err)
+			}
+		})
+	}
+}
+
+func TestSeatLockManagerAcquireSameSeatConcurrently(t *testing.T) {
+	setupSeatLockConfig(t, nil)
+	for _, backend := range seatLockBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			m, store := backend.new(), newFakeSeatStore(testShowID, 1)
+
+			const users = 20
+			var wg sync.WaitGroup
+			results := make(chan error, users)
+			for userID := uint(1); userID <= users; userID++ {
+				wg.Add(1)
+				go func(userID uint) {
+					defer wg.Done()
+					_, err := acquireSeats(m, store, userID, 1)
+					results <- err
+				}(userID)
+			}
+			wg.Wait()
+			close(results)
+
+			succeeded := 0
+			for err := range results {
+				if err == nil {
+					succeeded++
+					continue
+				}
+				expectErrorCode(t, err, constants.ErrCodeSeatUnavailable)
+			}
+			if succeeded != 1 {
+				t.Fatalf("%d concurrent Acquires of one seat succeeded, want 1", succeeded)
+			}
+			if held := heldSeats(t, m, store); len(held) != 1 {
+				t.Fatalf("seat held %d times, want 1", len(held))
+			}
+		})
+	}
+}
+
+func TestSeatLockManagerAcquireOverlappingSeatsConcurrently(t *testing.T) {
+	setupSeatLockConfig(t, nil)
+	for _, backend := range seatLockBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			const seatCount = 10
+			m, store := backend.new(), newFakeSeatStore(testShowID, seatCount)
+
+			// User n wants seats n and n+1, so neighbours compete for one seat each
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			acquired := make(map[uint][]uint)
+			for userID := uint(1); userID < seatCount; userID++ {
+				wg.Add(1)
+				go func(userID uint) {
+					defer wg.Done()
+					lock, err := acquireSeats(m, store, userID, userID, userID+1)
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					acquired[userID] = lock.SeatIDs()
+					mu.Unlock()
+				}(userID)
+			}
+			wg.Wait()
+
+			if len(acquired) == 0 {
+				t.Fatal("no overlapping Acquire succeeded")
+			}
+			held := heldSeats(t, m, store)
+			if len(held) != 2*len(acquired) {
+				t.Fatalf("%d seats held for %d successful pairs: %v", len(held), len(acquired), held)
+			}
+			for userID, seatIDs := range acquired {
+				for _, seatID := range seatIDs {
+					if held[seatID] != userID {
+						t.Fatalf("seat %d held by user %d, want %d", seatID, held[seatID], userID)
+					}
+				}
+			}
+			if empty := store.emptyHoldIDs(); len(empty) != 0 {
+				t.Fatalf("failed Acquires left holds without seats: %v", empty)
+			}
+		})
+	}
+}
+
+func TestSeatLockManagerHoldExpires(t *testing.T) {
+	setupSeatLockConfig(t, map[string]string{"SEAT_LOCK_DURATION": "50ms"})
+	for _, backend := range seatLockBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			m, store := backend.new(), newFakeSeatStore(testShowID, 2)
+
+			lock, err := acquireSeats(m, store, 1, 1, 2)
+			if err != nil {
+				t.Fatalf("Acquire: %v", err)
+			}
+			time.Sleep(100 * time.Millisecond)
+
+			if held := heldSeats(t, m, store); len(held) != 0 {
+				t.Fatalf("expired hold still listed: %v", held)
+			}
+			_, err = renewHold(m, store, lock.HoldID, 1)
+			expectErrorCode(t, err, constants.ErrCodeHoldExpired)
+			if _, err := acquireSeats(m, store, 2, 1, 2); err != nil {
+				t.Fatalf("seats of an expired hold are not free: %v", err)
+			}
+		})
+	}
+}
+
+func TestSeatLockManagerRenewStopsAtMaxExtensions(t *testing.T) {
+	setupSeatLockConfig(t, map[string]string{"SEAT_HOLD_MAX_EXTENSIONS": "2"})
+	for _, backend := range seatLockBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			m, store := backend.new(), newFakeSeatStore(testShowID, 1)
+
+			lock, err := acquireSeats(m, store, 1, 1)
+			if err != nil {
+				t.Fatalf("Acquire: %v", err)
+			}
+			_, err = renewHold(m, store, lock.HoldID, 2)
+			expectErrorCode(t, err, constants.ErrCodeNotSeatHolder)
+
+			for i := 0; i < 2; i++ {
+				time.Sleep(time.Millisecond)
+				renewed, err := renewHold(m, store, lock.HoldID, 1)
+				if err != nil {
+					t.Fatalf("renewal %d: %v", i+1, err)
+				}
+				if !renewed.ExpiresAt.After(lock.ExpiresAt) {
+					t.Fatalf("renewal %d did not move the expiry forward", i+1)
+				}
+				lock = renewed
+			}
+			_, err = renewHold(m, store, lock.HoldID, 1)
+			expectErrorCode(t, err, constants.ErrCodeHoldLimit)
+		})
+	}
+}
+
+func TestSeatLockManagerRenewStopsAtMaxDuration(t *testing.T) {
+	setupSeatLockConfig(t, map[string]string{
+		"SEAT_LOCK_DURATION":       "200ms",
+		"SEAT_HOLD_MAX_DURATION":   "300ms",
+		"SEAT_HOLD_MAX_EXTENSIONS": "5",
+	})
+	for _, backend := range seatLockBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			m, store := backend.new(), newFakeSeatStore(testShowID, 1)
+
+			lock, err := acquireSeats(m, store, 1, 1)
+			if err != nil {
+				t.Fatalf("Acquire: %v", err)
+			}
+			time.Sleep(150 * time.Millisecond)
+
+			renewed, err := renewHold(m, store, lock.HoldID, 1)
+			if err != nil {
+				t.Fatalf("Renew: %v", err)
+			}
+			if latest := lock.LockedAt.Add(300*time.Millisecond + 10*time.Millisecond); renewed.ExpiresAt.After(latest) {
+				t.Fatalf("renewed hold expires at %v, past the maximum hold time %v", renewed.ExpiresAt, latest)
+			}
+			_, err = renewHold(m, store, lock.HoldID, 1)
+			expectErrorCode(t, err, constants.ErrCodeHoldLimit)
+		})
+	}
+}
+
+// racingSeatStore sells takenSeatID just before the optimistic manager tries to claim it
+type racingSeatStore struct {
+	*fakeSeatStore
+	takenSeatID uint
+}
+
+func (s *racingSeatStore) LockSeatIfAvailable(ctx context.Context, id uint, version uint, lockedBefore time.Time, updates map[string]interface{}, event *model.SeatEvent) (bool, error) {
+	if id == s.takenSeatID {
+		sold := map[string]interface{}{"status": string(constants.SeatStatusSold)}
+		if err := s.fakeSeatStore.UpdateSeat(ctx, id, sold, nil); err != nil {
+			return false, err
+		}
+	}
+	return s.fakeSeatStore.LockSeatIfAvailable(ctx, id, version, lockedBefore, updates, event)
+}
+
+func TestOptimisticSeatLockManagerUndoesFailedAcquire(t *testing.T) {
+	setupSeatLockConfig(t, nil)
+	m, fake := NewOptimisticSeatLockManager(), newFakeSeatStore(testShowID, 3)
+	store := &racingSeatStore{fakeSeatStore: fake, takenSeatID: 3}
+
+	_, err := acquireSeats(m, store, 1, 1, 2, 3)
+	expectErrorCode(t, err, constants.ErrCodeSeatUnavailable)
+
+	if held := heldSeats(t, m, store); len(held) != 0 {
+		t.Fatalf("failed Acquire left seats held: %v", held)
+	}
+	if len(fake.data.holds) != 0 {
+		t.Fatalf("failed Acquire left %d seat holds behind", len(fake.data.holds))
+	}
+}
+
+// changingSeatStore lets another writer touch changedSeatID just before a lock on it is undone
+type changingSeatStore struct {
+	*racingSeatStore
+	changedSeatID uint
+}
+
+func (s *changingSeatStore) UpdateSeatIfVersion(ctx context.Context, id uint, version uint, updates map[string]interface{}, event *model.SeatEvent) (bool, error) {
+	if id == s.changedSeatID {
+		touched := map[string]interface{}{"status": string(constants.SeatStatusLocked)}
+		if err := s.fakeSeatStore.UpdateSeat(ctx, id, touched, nil); err != nil {
+			return false, err
+		}
+	}
+	return s.fakeSeatStore.UpdateSeatIfVersion(ctx, id, version, updates, event)
+}
+
+func TestOptimisticSeatLockManagerKeepsHoldOfSeatItCouldNotUndo(t *testing.T) {
+	setupSeatLockConfig(t, nil)
+	m, fake := NewOptimisticSeatLockManager(), newFakeSeatStore(testShowID, 3)
+	store := &changingSeatStore{racingSeatStore: &racingSeatStore{fakeSeatStore: fake, takenSeatID: 3}, changedSeatID: 1}
+
+	_, err := acquireSeats(m, store, 1, 1, 2, 3)
+	expectErrorCode(t, err, constants.ErrCodeSeatUnavailable)
+
+	if len(fake.data.holds) != 1 {
+		t.Fatalf("%d seat holds left, want the hold seat 1 still points at", len(fake.data.holds))
+	}
+	held := heldSeats(t, m, store)
+	if len(held) != 1 || held[1] != 1 {
+		t.Fatalf("want only seat 1 left held, got %v", held)
+	}
+	if empty := fake.emptyHoldIDs(); len(empty) != 0 {
+		t.Fatalf("holds without seats left behind: %v", empty)
+	}
+}
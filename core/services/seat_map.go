@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	"movie-booking/util/errors"
+)
+
+// GetSeatMap returns a show's seats laid out as rows and grid columns, with prices and a legend
+func (s *seatService) GetSeatMap(ctx context.Context, showID uint) (*types.SeatMapResponse, error) {
+	show, err := s.store.GetShowByID(ctx, showID)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "show not found")
+	}
+
+	// Read the version first: a change racing the read leaves the map newer than its version, never older
+	version, err := s.GetSeatMapVersion(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := s.seatsWithLocks(ctx, s.store, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	seatMap := buildSeatMap(show, seats, config.GetSeatLockDuration())
+	seatMap.Version = version
+	return seatMap, nil
+}
+
+// GetSeatMapVersion returns the show's seat version, which changes on every seat mutation and lock expiry
+func (s *seatService) GetSeatMapVersion(ctx context.Context, showID uint) (uint64, error) {
+	version, err := s.store.GetSeatVersionByShowID(ctx, showID, time.Now().Add(-config.GetSeatLockDuration()))
+	if err != nil {
+		return 0, err
+	}
+	return version + s.locks.Version(showID), nil
+}
+
+// buildSeatMap arranges seats (with locks already applied) into a grid whose column is the seat number.
+// Seats without usable positions are listed row by row instead, so none of them drops off the map.
+func buildSeatMap(show *model.Show, seats []model.ShowSeat, lockDuration time.Duration) *types.SeatMapResponse {
+	rows := groupSeatsByRow(seats)
+	price := show.TicketPrice()
+	if !hasSeatPositions(rows) {
+		return buildFlatSeatMap(show, rows, price, lockDuration)
+	}
+
+	// A column with no seat in any row is an aisle; one missing only in some rows is a gap there
+	columns := 0
+	occupied := map[int]bool{}
+	for _, row := range rows {
+		for _, seat := range row.seats {
+			occupied[seat.SeatNumber] = true
+			if seat.SeatNumber > columns {
+				columns = seat.SeatNumber
+			}
+		}
+	}
+
+	seatMap := &types.SeatMapResponse{
+		ShowID:     show.ID,
+		Screen:     types.SeatMapScreen{Position: constants.ScreenPositionFront, Label: "SCREEN"},
+		Columns:    columns,
+		Positioned: true,
+		Rows:       make([]types.SeatMapRow, 0, len(rows)),
+	}
+
+	categories := map[string]bool{}
+	for index, row := range rows {
+		byNumber := make(map[int]*model.ShowSeat, len(row.seats))
+		for i := range row.seats {
+			byNumber[row.seats[i].SeatNumber] = &row.seats[i]
+		}
+
+		mapRow := types.SeatMapRow{Label: row.label, Index: index, Cells: make([]types.SeatMapCell, 0, columns)}
+		for column := 1; column <= columns; column++ {
+			seat, ok := byNumber[column]
+			switch {
+			case ok:
+				categories[seat.Category] = true
+				mapRow.Cells = append(mapRow.Cells, types.SeatMapCell{
+					Type:   string(constants.SeatMapCellSeat),
+					Column: column,
+					Seat:   seatMapSeat(seat, price, lockDuration),
+				})
+			case occupied[column]:
+				mapRow.Cells = append(mapRow.Cells, types.SeatMapCell{Type: string(constants.SeatMapCellGap), Column: column})
+			default:
+				mapRow.Cells = append(mapRow.Cells, types.SeatMapCell{Type: string(constants.SeatMapCellAisle), Column: column})
+			}
+		}
+		seatMap.Rows = append(seatMap.Rows, mapRow)
+	}
+
+	seatMap.Legend = seatMapLegend(categories, price)
+	return seatMap
+}
+
+// buildFlatSeatMap lists each row's seats in order, one per column, for seats that cannot be placed on a grid
+func buildFlatSeatMap(show *model.Show, rows []seatRow, price float64, lockDuration time.Duration) *types.SeatMapResponse {
+	seatMap := &types.SeatMapResponse{
+		ShowID: show.ID,
+		Screen: types.SeatMapScreen{Position: constants.ScreenPositionFront, Label: "SCREEN"},
+		Rows:   make([]types.SeatMapRow, 0, len(rows)),
+	}
+
+	categories := map[string]bool{}
+	for index, row := range rows {
+		if len(row.seats) > seatMap.Columns {
+			seatMap.Columns = len(row.seats)
+		}
+		mapRow := types.SeatMapRow{Label: row.label, Index: index, Cells: make([]types.SeatMapCell, 0, len(row.seats))}
+		for i := range row.seats {
+			categories[row.seats[i].Category] = true
+			mapRow.Cells = append(mapRow.Cells, types.SeatMapCell{
+				Type:   string(constants.SeatMapCellSeat),
+				Column: i + 1,
+				Seat:   seatMapSeat(&row.seats[i], price, lockDuration),
+			})
+		}
+		seatMap.Rows = append(seatMap.Rows, mapRow)
+	}
+
+	seatMap.Legend = seatMapLegend(categories, price)
+	return seatMap
+}
+
+// hasSeatPositions reports whether every seat has a row and a seat number no other seat of its row shares
+func hasSeatPositions(rows []seatRow) bool {
+	for _, row := range rows {
+		if !isRowNumbered(row) {
+			return false
+		}
+		// Seats are sorted by number, so a shared number sits next to its twin
+		for i := 1; i < len(row.seats); i++ {
+			if row.seats[i].SeatNumber == row.seats[i-1].SeatNumber {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// seatMapSeat converts a seat for the seat map
+func seatMapSeat(seat *model.ShowSeat, price float64, lockDuration time.Duration) *types.SeatMapSeat {
+	mapSeat := &types.SeatMapSeat{
+		ID:       seat.ID,
+		Name:     seat.SeatName,
+		Number:   seat.SeatNumber,
+		Category: seat.Category,
+		Price:    price,
+		Status:   seat.Status,
+	}
+	if seat.Status == string(constants.SeatStatusLocked) && seat.LockedAt != nil {
+		expiresAt := seat.LockedAt.Add(lockDuration)
+		mapSeat.ExpiresAt = &expiresAt
+	}
+	if seat.Status == string(constants.SeatStatusBlocked) && seat.BlockReason != nil {
+		mapSeat.BlockReason = *seat.BlockReason
+	}
+	return mapSeat
+}
+
+// seatMapLegend lists the categories present in the show, in their defined order, followed by every seat status
+func seatMapLegend(categories map[string]bool, price float64) []types.SeatMapLegendEntry {
+	legend := []types.SeatMapLegendEntry{}
+	for _, category := range constants.ValidSeatCategories {
+		if !categories[string(category)] {
+			continue
+		}
+		categoryPrice := price
+		legend = append(legend, types.SeatMapLegendEntry{
+			Kind:  string(constants.SeatMapLegendCategory),
+			Value: string(category),
+			Label: legendLabel(string(category)),
+			Price: &categoryPrice,
+		})
+	}
+	for _, status := range constants.ValidSeatStatuses {
+		legend = append(legend, types.SeatMapLegendEntry{
+			Kind:  string(constants.SeatMapLegendStatus),
+			Value: string(status),
+			Label: legendLabel(string(status)),
+		})
+	}
+	return legend
+}
+
+// legendLabel turns an upper-case constant such as "PREMIUM" into "Premium"
+func legendLabel(value string) string {
+	lower := strings.ToLower(strings.ReplaceAll(value, "_", " "))
+	if lower == "" {
+		return lower
+	}
+	return strings.ToUpper(lower[:1]) + lower[1:]
+}
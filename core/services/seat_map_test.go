@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/model"
+)
+
+func mapSeat(id uint, name, row string, number int) model.ShowSeat {
+	return model.ShowSeat{
+		ID:         id,
+		SeatName:   name,
+		RowLabel:   row,
+		SeatNumber: number,
+		Category:   "STANDARD",
+		Status:     string(constants.SeatStatusAvailable),
+	}
+}
+
+func TestBuildSeatMapPlacesSeatsByNumber(t *testing.T) {
+	seats := []model.ShowSeat{mapSeat(1, "A1", "A", 1), mapSeat(2, "A3", "A", 3), mapSeat(3, "B1", "B", 1)}
+	seatMap := buildSeatMap(&model.Show{ID: 1}, seats, time.Minute)
+
+	if !seatMap.Positioned || seatMap.Columns != 3 {
+		t.Fatalf("positioned = %v, columns = %d; want true, 3", seatMap.Positioned, seatMap.Columns)
+	}
+	if cell := seatMap.Rows[0].Cells[1]; cell.Type != string(constants.SeatMapCellAisle) {
+		t.Fatalf("column 2 is %s, want an aisle", cell.Type)
+	}
+	if cell := seatMap.Rows[1].Cells[2]; cell.Type != string(constants.SeatMapCellGap) {
+		t.Fatalf("row B column 3 is %s, want a gap", cell.Type)
+	}
+}
+
+func TestBuildSeatMapListsUnpositionedSeats(t *testing.T) {
+	tests := []struct {
+		name  string
+		seats []model.ShowSeat
+	}{
+		{"no positions", []model.ShowSeat{mapSeat(1, "S1", "", 0), mapSeat(2, "S2", "", 0), mapSeat(3, "S3", "", 0)}},
+		{"shared numbers", []model.ShowSeat{mapSeat(1, "A1", "A", 1), mapSeat(2, "A1b", "A", 1), mapSeat(3, "A2", "A", 2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seatMap := buildSeatMap(&model.Show{ID: 1}, tt.seats, time.Minute)
+
+			if seatMap.Positioned {
+				t.Fatal("map of unpositioned seats is marked positioned")
+			}
+			listed := 0
+			for _, row := range seatMap.Rows {
+				for _, cell := range row.Cells {
+					if cell.Seat != nil {
+						listed++
+					}
+				}
+			}
+			if listed != len(tt.seats) || seatMap.Columns != len(tt.seats) {
+				t.Fatalf("listed %d seats in %d columns, want %d", listed, seatMap.Columns, len(tt.seats))
+			}
+		})
+	}
+}
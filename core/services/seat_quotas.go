@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+)
+
+// checkUserSeatQuotas enforces the per-show seat limit and the concurrent hold limit for a user.
+// It locks the user row so parallel lock requests from the same user are counted one after another.
+func checkUserSeatQuotas(ctx context.Context, tx model.DataStore, locks coretypes.SeatLockManager, userID, showID uint, newSeats int) error {
+	if _, err := tx.GetUserByIDForUpdate(ctx, userID); err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	return countUserSeatQuotas(ctx, tx, locks, userID, showID, newSeats, 0)
+}
+
+// countUserSeatQuotas checks the quotas against the user's current holds and bookings, ignoring the hold
+// excludeHoldID so a hold that was already acquired can be checked as if it were new.
+// Holds are counted per show: locking seats one at a time for the same show never hits the hold limit.
+func countUserSeatQuotas(ctx context.Context, store model.DataStore, locks coretypes.SeatLockManager, userID, showID uint, newSeats int, excludeHoldID uint) error {
+	userLocks, err := locks.Inspect(ctx, store, coretypes.SeatLockFilter{UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to inspect seat locks: %w", err)
+	}
+	var held int64
+	heldShows := make(map[uint]bool)
+	for _, lock := range userLocks {
+		if excludeHoldID != 0 && lock.HoldID == excludeHoldID {
+			continue
+		}
+		if lock.ShowID == showID {
+			held += int64(len(lock.Seats))
+		}
+		heldShows[lock.ShowID] = true
+	}
+	holds := len(heldShows)
+	if !heldShows[showID] {
+		holds++
+	}
+
+	owned, err := store.CountBookingsByUserAndShow(ctx, userID, showID)
+	if err != nil {
+		return err
+	}
+	if maxSeats := config.GetMaxSeatsPerUserPerShow(); held+owned+int64(newSeats) > int64(maxSeats) {
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatQuotaExceeded,
+			fmt.Sprintf("you can hold or own at most %d seats for this show", maxSeats))
+	}
+
+	if maxHolds := config.GetMaxConcurrentHoldsPerUser(); holds > maxHolds {
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeHoldQuotaExceeded,
+			fmt.Sprintf("you can hold seats for at most %d shows at a time; release the seats held for another show first", maxHolds))
+	}
+
+	return nil
+}
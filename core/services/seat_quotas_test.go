@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"movie-booking/constants"
+)
+
+func TestCountUserSeatQuotasCountsHoldsPerShow(t *testing.T) {
+	setupSeatLockConfig(t, map[string]string{"MAX_CONCURRENT_HOLDS_PER_USER": "1"})
+	for _, backend := range seatLockBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			m, store := backend.new(), newFakeSeatStore(testShowID, 3)
+
+			for seatID := uint(1); seatID <= 2; seatID++ {
+				if _, err := acquireSeats(m, store, 1, seatID); err != nil {
+					t.Fatalf("Acquire seat %d: %v", seatID, err)
+				}
+			}
+
+			if err := countUserSeatQuotas(context.Background(), store, m, 1, testShowID, 1, 0); err != nil {
+				t.Fatalf("another hold on the same show was refused: %v", err)
+			}
+			err := countUserSeatQuotas(context.Background(), store, m, 1, testShowID+1, 1, 0)
+			expectErrorCode(t, err, constants.ErrCodeHoldQuotaExceeded)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/model"
+)
+
+// SeatPosition describes where a seat sits relative to the screen
+type SeatPosition struct {
+	RowIndex     int     // 0 is the row nearest the screen
+	RowCount     int     // Number of rows in the hall
+	CentreOffset float64 // Distance from the centre of the row, in seats
+	RowHalfWidth float64 // Half the width of the row, in seats
+}
+
+// SeatScorer ranks seats for best-available selection; lower scores are better
+type SeatScorer interface {
+	ScoreSeat(pos SeatPosition) float64
+}
+
+// RowPreferenceScorer prefers seats near the row centre and near a target depth in the hall
+type RowPreferenceScorer struct {
+	IdealRowFraction float64 // 0 = front row, 1 = back row
+	RowWeight        float64
+	CentreWeight     float64
+}
+
+// ScoreSeat implements SeatScorer
+func (s RowPreferenceScorer) ScoreSeat(pos SeatPosition) float64 {
+	rowFraction := 0.0
+	if pos.RowCount > 1 {
+		rowFraction = float64(pos.RowIndex) / float64(pos.RowCount-1)
+	}
+	centre := 0.0
+	if pos.RowHalfWidth > 0 {
+		centre = pos.CentreOffset / pos.RowHalfWidth
+	}
+	return s.RowWeight*math.Abs(rowFraction-s.IdealRowFraction) + s.CentreWeight*centre
+}
+
+// seatScorersMu guards seatScorers, which request goroutines read while scorers may still be registered
+var seatScorersMu sync.RWMutex
+
+// seatScorers maps each screen layout to its scorer
+var seatScorers = map[string]SeatScorer{
+	string(constants.ScreenLayoutStandard):    RowPreferenceScorer{IdealRowFraction: 0.6, RowWeight: 1.0, CentreWeight: 1.0},
+	string(constants.ScreenLayoutLargeFormat): RowPreferenceScorer{IdealRowFraction: 0.75, RowWeight: 1.5, CentreWeight: 1.0},
+}
+
+// RegisterSeatScorer plugs in (or replaces) the scorer used for a screen layout
+func RegisterSeatScorer(layout string, scorer SeatScorer) {
+	seatScorersMu.Lock()
+	defer seatScorersMu.Unlock()
+	seatScorers[layout] = scorer
+}
+
+// seatScorerForShow picks the scorer of the show's screen layout, defaulting to the standard one
+func seatScorerForShow(show *model.Show) SeatScorer {
+	seatScorersMu.RLock()
+	defer seatScorersMu.RUnlock()
+	if show.Screen != nil {
+		if scorer, ok := seatScorers[show.Screen.Layout]; ok {
+			return scorer
+		}
+	}
+	return seatScorers[string(constants.ScreenLayoutStandard)]
+}
+
+// seatRow is one row of a show's seats in seat-number order
+type seatRow struct {
+	label string
+	seats []model.ShowSeat
+}
+
+// groupSeatsByRow splits seats into rows ordered from the screen backwards
+func groupSeatsByRow(seats []model.ShowSeat) []seatRow {
+	byLabel := map[string][]model.ShowSeat{}
+	for _, seat := range seats {
+		byLabel[seat.RowLabel] = append(byLabel[seat.RowLabel], seat)
+	}
+
+	rows := make([]seatRow, 0, len(byLabel))
+	for label, rowSeats := range byLabel {
+		sort.Slice(rowSeats, func(i, j int) bool {
+			if rowSeats[i].SeatNumber != rowSeats[j].SeatNumber {
+				return rowSeats[i].SeatNumber < rowSeats[j].SeatNumber
+			}
+			return rowSeats[i].SeatName < rowSeats[j].SeatName
+		})
+		rows = append(rows, seatRow{label: label, seats: rowSeats})
+	}
+
+	// "B" before "AA": shorter labels first, then alphabetical
+	sort.Slice(rows, func(i, j int) bool {
+		if len(rows[i].label) != len(rows[j].label) {
+			return len(rows[i].label) < len(rows[j].label)
+		}
+		return rows[i].label < rows[j].label
+	})
+	return rows
+}
+
+// isSeatFree reports whether a seat can be locked right now
+func isSeatFree(seat *model.ShowSeat, now time.Time, lockDuration time.Duration) bool {
+	return checkSeatLockable(seat, now, lockDuration) == nil
+}
+
+// findBestSeatBlock returns the best-scoring run of adjacent free seats, or nil if none fits
+func findBestSeatBlock(seats []model.ShowSeat, quantity int, category string, avoidGaps bool, scorer SeatScorer, now time.Time, lockDuration time.Duration) []model.ShowSeat {
+	rows := groupSeatsByRow(seats)
+
+	var best []model.ShowSeat
+	bestScore := math.MaxFloat64
+	for rowIndex, row := range rows {
+		first := row.seats[0].SeatNumber
+		last := row.seats[len(row.seats)-1].SeatNumber
+		centre := float64(first+last) / 2
+		halfWidth := float64(last-first) / 2
+
+		for start := 0; start+quantity <= len(row.seats); start++ {
+			block := row.seats[start : start+quantity]
+			if !isSeatBlockUsable(block, category, now, lockDuration) {
+				continue
+			}
+			if avoidGaps && findStrandedSeat(row.seats, seatIDSet(block), now, lockDuration) != nil {
+				continue
+			}
+
+			score := 0.0
+			for _, seat := range block {
+				score += scorer.ScoreSeat(SeatPosition{
+					RowIndex:     rowIndex,
+					RowCount:     len(rows),
+					CentreOffset: math.Abs(float64(seat.SeatNumber) - centre),
+					RowHalfWidth: halfWidth,
+				})
+			}
+			if score < bestScore {
+				bestScore = score
+				best = block
+			}
+		}
+	}
+	return best
+}
+
+// isSeatBlockUsable checks that a block is free, in the wanted category and has no gap in numbering
+func isSeatBlockUsable(block []model.ShowSeat, category string, now time.Time, lockDuration time.Duration) bool {
+	for i := range block {
+		if !isSeatFree(&block[i], now, lockDuration) {
+			return false
+		}
+		if category != "" && block[i].Category != category {
+			return false
+		}
+		if i > 0 && block[i].SeatNumber != block[i-1].SeatNumber+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// seatIDSet indexes seats by ID
+func seatIDSet(seats []model.ShowSeat) map[uint]bool {
+	set := make(map[uint]bool, len(seats))
+	for _, seat := range seats {
+		set[seat.ID] = true
+	}
+	return set
+}
@@ -3,53 +3,298 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"time"
 
 	"movie-booking/api/v1/types"
 	"movie-booking/config"
 	"movie-booking/constants"
+	"movie-booking/core/events"
 	"movie-booking/core/model"
 	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
 )
 
+// bestAvailableAttempts bounds how often best-available selection retries after losing a race
+const bestAvailableAttempts = 3
+
 type seatService struct {
-	store model.DataStore
+	store    model.DataStore
+	events   *events.Hub
+	locks    coretypes.SeatLockManager
+	waitlist coretypes.SeatAvailabilityListener
 }
 
 // NewSeatService creates a new seat service
 func NewSeatService(clients *coretypes.Clients, store model.DataStore) SeatServiceInterface {
-	return &seatService{store: store}
+	return newSeatService(clients, store)
+}
+
+// newSeatService creates the seat service for other services that lock seats on a user's behalf
+func newSeatService(clients *coretypes.Clients, store model.DataStore) *seatService {
+	return &seatService{store: store, events: clients.SeatEvents, locks: seatLockManager(clients), waitlist: clients.Waitlist}
 }
 
 func (s *seatService) GetSeatsByShowID(ctx context.Context, showID uint) ([]model.ShowSeat, error) {
-	seats, err := s.store.GetSeatsByShowID(ctx, showID)
+	return s.seatsWithLocks(ctx, s.store, showID)
+}
+
+// seatsWithLocks reads a show's seats and overlays the lock manager's active holds
+func (s *seatService) seatsWithLocks(ctx context.Context, store model.DataStore, showID uint) ([]model.ShowSeat, error) {
+	seats, err := store.GetSeatsByShowID(ctx, showID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seats: %w", err)
 	}
 
-	// Lazy lock expiration: treat expired locks as AVAILABLE
-	lockDuration := config.GetSeatLockDuration()
-	now := time.Now()
-	for i := range seats {
-		if seats[i].Status == string(constants.SeatStatusLocked) && seats[i].LockedAt != nil {
-			if now.Sub(*seats[i].LockedAt) > lockDuration {
-				// Lock expired, treat as available (optionally update in DB)
-				seats[i].Status = string(constants.SeatStatusAvailable)
-				seats[i].LockedAt = nil
-				seats[i].UserID = nil
-			}
-		}
+	locks, err := s.locks.Inspect(ctx, store, coretypes.SeatLockFilter{ShowID: showID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect seat locks: %w", err)
 	}
 
+	applySeatLocks(seats, locks, time.Now(), config.GetSeatLockDuration())
 	return seats, nil
 }
 
-// LockSeat implements the core concurrency strategy with row-level locking
+// SubscribeSeatEvents streams status changes for a show's seats, replaying events after lastEventID
+func (s *seatService) SubscribeSeatEvents(ctx context.Context, showID uint, lastEventID uint64) (*events.Subscription, []events.SeatEvent, error) {
+	if s.events == nil {
+		return nil, nil, errors.NewHTTPError(http.StatusServiceUnavailable, "seat updates are not available")
+	}
+	if _, err := s.store.GetShowByID(ctx, showID); err != nil {
+		return nil, nil, errors.NewHTTPError(http.StatusNotFound, "show not found")
+	}
+
+	sub, replay := s.events.Subscribe(showID, lastEventID)
+	return sub, replay, nil
+}
+
+// LockSeat holds a single seat through the configured seat lock manager
 func (s *seatService) LockSeat(ctx context.Context, seatID uint, userID uint) (*types.LockSeatResponse, error) {
+	seat, err := s.store.GetSeatByID(ctx, seatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat: %w", err)
+	}
+
+	lock, err := s.lockSeats(ctx, seat.ShowID, []uint{seatID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LockSeatResponse{
+		Message:   "Locked",
+		HoldID:    lock.HoldID,
+		ExpiresAt: lock.ExpiresAt,
+	}, nil
+}
+
+// LockSeats locks several seats of one show atomically: either every seat is locked or none is
+func (s *seatService) LockSeats(ctx context.Context, showID uint, seatIDs []uint, userID uint) (*types.LockSeatResponse, error) {
+	if len(seatIDs) > config.GetMaxSeatsPerHold() {
+		return nil, errors.NewHTTPError(http.StatusBadRequest,
+			fmt.Sprintf("at most %d seats can be locked at once", config.GetMaxSeatsPerHold()))
+	}
+
+	// Lock rows in ascending ID order so concurrent multi-seat requests cannot deadlock
+	sortedIDs := make([]uint, len(seatIDs))
+	copy(sortedIDs, seatIDs)
+	sort.Slice(sortedIDs, func(i, j int) bool { return sortedIDs[i] < sortedIDs[j] })
+
+	lock, err := s.lockSeats(ctx, showID, sortedIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LockSeatResponse{
+		Message:   "Locked",
+		HoldID:    lock.HoldID,
+		SeatIDs:   sortedIDs,
+		ExpiresAt: lock.ExpiresAt,
+	}, nil
+}
+
+// lockSeats validates a selection of sorted seat IDs and acquires one hold over it
+func (s *seatService) lockSeats(ctx context.Context, showID uint, seatIDs []uint, userID uint) (*coretypes.SeatLock, error) {
+	var lock *coretypes.SeatLock
+	err := s.withSeatLockStore(ctx, func(store model.DataStore) error {
+		// Step 1: Reject locks outside the show's sales window
+		show, err := store.GetShowByID(ctx, showID)
+		if err != nil {
+			return fmt.Errorf("failed to get show: %w", err)
+		}
+		lockDuration := config.GetSeatLockDuration()
+		now := time.Now()
+		if err := checkSalesWindow(show, now); err != nil {
+			return err
+		}
+
+		// Step 2: Validate the selection against the seat map and, inside a transaction, the user's quotas
+		if err := s.checkSingleSeatGaps(ctx, store, show, seatIDs, now, lockDuration); err != nil {
+			return err
+		}
+		if s.locks.UsesTransaction() {
+			if err := checkUserSeatQuotas(ctx, store, s.locks, userID, showID, len(seatIDs)); err != nil {
+				return err
+			}
+		}
+
+		// Step 3: Acquire the hold - allowed if every seat is AVAILABLE or its lock has expired
+		lock, err = s.locks.Acquire(ctx, store, showID, userID, seatIDs)
+		if err != nil {
+			return err
+		}
+
+		// Without a transaction the quotas are checked after the fact, and the hold is given back if they are exceeded
+		if !s.locks.UsesTransaction() {
+			if err := countUserSeatQuotas(ctx, store, s.locks, userID, showID, len(seatIDs), lock.HoldID); err != nil {
+				s.locks.Release(ctx, store, coretypes.SeatLockRelease{HoldID: lock.HoldID, UserID: userID})
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishSeatEvents(s.events, constants.SeatEventLocked, lock.Seats, &lock.HoldID, &lock.ExpiresAt)
+	return lock, nil
+}
+
+// LockBestAvailable picks the best block of adjacent free seats for the show's screen layout and locks it
+func (s *seatService) LockBestAvailable(ctx context.Context, showID uint, quantity int, category string, userID uint) (*types.LockSeatResponse, error) {
+	show, err := s.store.GetShowByID(ctx, showID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get show: %w", err)
+	}
+	scorer := seatScorerForShow(show)
+
+	// Another user may take the chosen seats between selection and locking; pick again when that happens
+	for attempt := 1; ; attempt++ {
+		seats, err := s.seatsWithLocks(ctx, s.store, showID)
+		if err != nil {
+			return nil, err
+		}
+
+		block := findBestSeatBlock(seats, quantity, category, show.Theatre.PreventSingleSeatGaps, scorer, time.Now(), config.GetSeatLockDuration())
+		if block == nil {
+			return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeNoSeatsAvailable,
+				fmt.Sprintf("no block of %d adjacent seats is available", quantity))
+		}
+
+		seatIDs := make([]uint, len(block))
+		seatNames := make([]string, len(block))
+		for i, seat := range block {
+			seatIDs[i] = seat.ID
+			seatNames[i] = seat.SeatName
+		}
+
+		result, err := s.LockSeats(ctx, showID, seatIDs, userID)
+		if err != nil {
+			if httpErr, ok := errors.IsHTTPError(err); ok && httpErr.Code == constants.ErrCodeSeatUnavailable && attempt < bestAvailableAttempts {
+				continue
+			}
+			return nil, err
+		}
+		result.SeatNames = seatNames
+		return result, nil
+	}
+}
+
+// ReleaseSeat frees a locked seat immediately; only the holder or staff may release it
+func (s *seatService) ReleaseSeat(ctx context.Context, seatID uint, userID uint, isStaff bool) (*types.ReleaseSeatResponse, error) {
+	var released []model.ShowSeat
+	err := s.withSeatLockStore(ctx, func(store model.DataStore) error {
+		// Validate state and ownership, then return the seat to AVAILABLE
+		var err error
+		released, err = s.locks.Release(ctx, store, coretypes.SeatLockRelease{
+			SeatIDs: []uint{seatID},
+			UserID:  userID,
+			IsStaff: isStaff,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishSeatEvents(s.events, constants.SeatEventReleased, released, nil, nil)
+	notifySeatsFreed(s.waitlist, released)
+
+	return &types.ReleaseSeatResponse{
+		Message: "Released",
+		SeatIDs: []uint{seatID},
+	}, nil
+}
+
+// ReleaseHold frees every seat still locked under a hold; only the holder or staff may release it
+func (s *seatService) ReleaseHold(ctx context.Context, holdID uint, userID uint, isStaff bool) (*types.ReleaseSeatResponse, error) {
+	var releasedSeats []model.ShowSeat
+	err := s.withSeatLockStore(ctx, func(store model.DataStore) error {
+		var err error
+		releasedSeats, err = s.locks.Release(ctx, store, coretypes.SeatLockRelease{
+			HoldID:  holdID,
+			UserID:  userID,
+			IsStaff: isStaff,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishSeatEvents(s.events, constants.SeatEventReleased, releasedSeats, nil, nil)
+	notifySeatsFreed(s.waitlist, releasedSeats)
+
+	released := make([]uint, len(releasedSeats))
+	for i, seat := range releasedSeats {
+		released[i] = seat.ID
+	}
+
+	return &types.ReleaseSeatResponse{
+		Message: "Released",
+		SeatIDs: released,
+	}, nil
+}
+
+// RenewHold extends an unexpired hold, capped by the maximum total hold time and extension count
+func (s *seatService) RenewHold(ctx context.Context, holdID uint, userID uint) (*types.LockSeatResponse, error) {
+	var lock *coretypes.SeatLock
+	err := s.withSeatLockStore(ctx, func(store model.DataStore) error {
+		var err error
+		lock, err = s.locks.Renew(ctx, store, holdID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribers see a renewal as a fresh lock with a later expiry
+	publishSeatEvents(s.events, constants.SeatEventLocked, lock.Seats, &holdID, &lock.ExpiresAt)
+
+	return &types.LockSeatResponse{
+		Message:   "Renewed",
+		HoldID:    holdID,
+		SeatIDs:   lock.SeatIDs(),
+		ExpiresAt: lock.ExpiresAt,
+	}, nil
+}
+
+// withSeatLockStore runs fn inside a transaction when the seat lock manager needs one, otherwise
+// directly against the store so no connection is held open while seats are claimed
+func (s *seatService) withSeatLockStore(ctx context.Context, fn func(store model.DataStore) error) error {
+	if !s.locks.UsesTransaction() {
+		return fn(s.store)
+	}
+	return s.withTransaction(ctx, fn)
+}
+
+// withTransaction runs fn inside a transaction, committing it if fn succeeds
+func (s *seatService) withTransaction(ctx context.Context, fn func(tx model.DataStore) error) error {
 	// Begin transaction
 	tx, err := s.store.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	// Ensure rollback on panic or error
@@ -60,60 +305,71 @@ func (s *seatService) LockSeat(ctx context.Context, seatID uint, userID uint) (*
 		}
 	}()
 
-	// Step 1: Lock the seat row using FOR UPDATE
-	seat, err := tx.GetSeatByIDForUpdate(ctx, seatID)
-	if err != nil {
+	if err := fn(tx); err != nil {
 		tx.Rollback(ctx)
-		return nil, fmt.Errorf("failed to get seat: %w", err)
+		return err
 	}
 
-	// Step 2: Validate - allow if AVAILABLE or if LOCKED but expired
-	lockDuration := config.GetSeatLockDuration()
-	now := time.Now()
-	canLock := false
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
 
-	if seat.Status == string(constants.SeatStatusAvailable) {
-		canLock = true
-	} else if seat.Status == string(constants.SeatStatusLocked) && seat.LockedAt != nil {
-		// Check if lock is expired
-		if now.Sub(*seat.LockedAt) > lockDuration {
-			canLock = true
-		} else {
-			// Lock is still valid
-			tx.Rollback(ctx)
-			return nil, fmt.Errorf("seat is already locked")
-		}
-	} else if seat.Status == string(constants.SeatStatusSold) {
-		tx.Rollback(ctx)
-		return nil, fmt.Errorf("seat is already sold")
+// checkSingleSeatGaps rejects selections that would strand a single empty seat, when the theatre enables the rule
+func (s *seatService) checkSingleSeatGaps(ctx context.Context, tx model.DataStore, show *model.Show, seatIDs []uint, now time.Time, lockDuration time.Duration) error {
+	if !show.Theatre.PreventSingleSeatGaps {
+		return nil
 	}
 
-	if !canLock {
-		tx.Rollback(ctx)
-		return nil, fmt.Errorf("seat cannot be locked")
+	seats, err := s.seatsWithLocks(ctx, tx, show.ID)
+	if err != nil {
+		return err
 	}
 
-	// Step 3: Update seat to LOCKED
-	lockedAt := now
-	updates := map[string]interface{}{
-		"status":   string(constants.SeatStatusLocked),
-		"locked_at": lockedAt,
-		"user_id":   userID,
+	selected := make(map[uint]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		selected[id] = true
 	}
 
-	if err := tx.UpdateSeat(ctx, seatID, updates); err != nil {
-		tx.Rollback(ctx)
-		return nil, fmt.Errorf("failed to lock seat: %w", err)
+	// A seat someone else already holds is reported as unavailable rather than as a gap
+	for i := range seats {
+		if selected[seats[i].ID] {
+			if err := checkSeatLockable(&seats[i], now, lockDuration); err != nil {
+				return err
+			}
+		}
 	}
 
-	// Step 4: Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if stranded := findStrandedSeat(seats, selected, now, lockDuration); stranded != nil {
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSingleSeatGap,
+			fmt.Sprintf("this selection would leave seat %s empty on its own; please include it or choose adjacent seats", stranded.SeatName))
 	}
+	return nil
+}
 
-	expiresAt := lockedAt.Add(lockDuration)
-	return &types.LockSeatResponse{
-		Message:   "Locked",
-		ExpiresAt: expiresAt,
-	}, nil
+// checkSeatLockable allows a lock if the seat is AVAILABLE or its previous lock has expired
+func checkSeatLockable(seat *model.ShowSeat, now time.Time, lockDuration time.Duration) error {
+	switch seat.Status {
+	case string(constants.SeatStatusAvailable):
+		return nil
+	case string(constants.SeatStatusLocked):
+		if seat.LockedAt != nil && now.Sub(*seat.LockedAt) > lockDuration {
+			return nil
+		}
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable,
+			fmt.Sprintf("seat %s is already locked", seat.SeatName))
+	case string(constants.SeatStatusSold):
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable,
+			fmt.Sprintf("seat %s is already sold", seat.SeatName))
+	case string(constants.SeatStatusBlocked):
+		message := fmt.Sprintf("seat %s is blocked", seat.SeatName)
+		if seat.BlockReason != nil && *seat.BlockReason != "" {
+			message += ": " + *seat.BlockReason
+		}
+		return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable, message)
+	}
+	return errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSeatUnavailable,
+		fmt.Sprintf("seat %s cannot be locked", seat.SeatName))
 }
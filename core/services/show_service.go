@@ -17,12 +17,22 @@ func NewShowService(clients *coretypes.Clients, store model.DataStore) ShowServi
 	return &showService{store: store}
 }
 
-func (s *showService) GetShowsByMovieID(ctx context.Context, movieID uint) ([]model.Show, error) {
-	shows, err := s.store.GetShowsByMovieID(ctx, movieID)
+func (s *showService) GetShowsByMovieID(ctx context.Context, movieID uint, filter model.ShowFilter) ([]model.Show, error) {
+	shows, err := s.store.GetShowsByMovieID(ctx, movieID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shows: %w", err)
 	}
-	return shows, nil
+
+	// Date filters are interpreted in each theatre's own time zone
+	result := make([]model.Show, 0, len(shows))
+	for i := range shows {
+		if filter.Date != nil && !isOnLocalDate(&shows[i], *filter.Date) {
+			continue
+		}
+		localizeShow(&shows[i])
+		result = append(result, shows[i])
+	}
+	return result, nil
 }
 
 func (s *showService) GetShowByID(ctx context.Context, id uint) (*model.Show, error) {
@@ -30,5 +40,6 @@ func (s *showService) GetShowByID(ctx context.Context, id uint) (*model.Show, er
 	if err != nil {
 		return nil, fmt.Errorf("failed to get show: %w", err)
 	}
+	localizeShow(show)
 	return show, nil
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	"github.com/sirupsen/logrus"
+)
+
+// invalidTimeZones remembers the zones already reported, so a bad theatre row is logged once and not per request
+var invalidTimeZones sync.Map
+
+// theatreLocation resolves a theatre's IANA time zone. Theatres are written through SQL as well as the model, so a
+// zone that cannot be loaded is logged and UTC is used.
+func theatreLocation(theatre *model.Theatre) *time.Location {
+	if theatre == nil || theatre.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(theatre.TimeZone)
+	if err != nil {
+		if _, reported := invalidTimeZones.LoadOrStore(theatre.TimeZone, true); !reported {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"theatreID": theatre.ID,
+				"timeZone":  theatre.TimeZone,
+			}).Error("Invalid theatre time zone, showing its show times in UTC")
+		}
+		return time.UTC
+	}
+	return loc
+}
+
+// localizeShow fills in the theatre-local start time and UTC offset of a show
+func localizeShow(show *model.Show) {
+	local := show.StartTime.In(theatreLocation(&show.Theatre))
+	show.LocalStartTime = local.Format(time.RFC3339)
+	show.UTCOffset = local.Format("-07:00")
+}
+
+// isOnLocalDate reports whether a show starts on the given calendar day in its theatre's zone
+func isOnLocalDate(show *model.Show, date time.Time) bool {
+	local := show.StartTime.In(theatreLocation(&show.Theatre))
+	return local.Format(constants.DateLayout) == date.Format(constants.DateLayout)
+}
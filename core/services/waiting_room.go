@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// waitingRoomService queues users for shows whose waiting room is switched on and admits
+// at most WAITING_ROOM_MAX_ADMITTED of them at a time. Queues and admissions live in MySQL,
+// so every replica sees the same slots; changes to a show's room are serialized on its show row.
+type waitingRoomService struct {
+	store model.DataStore
+}
+
+// waitingRoom is the queue and the admitted entries of one show, read under the show's row lock
+type waitingRoom struct {
+	queue    []*model.WaitingRoomEntry        // QUEUED entries in join order
+	admitted map[uint]*model.WaitingRoomEntry // keyed by user
+}
+
+// admissionClaims are the claims of an admission token. The user is carried in the subject
+// rather than user_id so an admission token is never accepted as a login token.
+type admissionClaims struct {
+	ShowID uint `json:"show_id"`
+	jwt.RegisteredClaims
+}
+
+// NewWaitingRoomService creates a new waiting room service
+func NewWaitingRoomService(clients *coretypes.Clients, store model.DataStore) WaitingRoomServiceInterface {
+	return &waitingRoomService{store: store}
+}
+
+// SetWaitingRoom switches a show's waiting room on or off; switching it off drops the queue
+func (s *waitingRoomService) SetWaitingRoom(ctx context.Context, showID uint, enabled bool) (*types.WaitingRoomSettingsResponse, error) {
+	res := &types.WaitingRoomSettingsResponse{
+		ShowID:      showID,
+		Enabled:     enabled,
+		MaxAdmitted: config.GetWaitingRoomMaxAdmitted(),
+	}
+	err := s.withWaitingRoom(ctx, showID, func(tx model.DataStore, show *model.Show, room *waitingRoom, now time.Time) error {
+		if err := tx.SetShowWaitingRoom(ctx, showID, enabled); err != nil {
+			return err
+		}
+		if !enabled {
+			return tx.DeleteWaitingRoomEntriesByShow(ctx, showID)
+		}
+		if err := s.advance(ctx, tx, room, now); err != nil {
+			return err
+		}
+		res.Admitted = len(room.admitted)
+		res.Queued = len(room.queue)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// JoinWaitingRoom puts the user at the back of the show's queue, or reports their place if already there
+func (s *waitingRoomService) JoinWaitingRoom(ctx context.Context, showID uint, userID uint) (*types.WaitingRoomStatusResponse, error) {
+	var res *types.WaitingRoomStatusResponse
+	err := s.withWaitingRoom(ctx, showID, func(tx model.DataStore, show *model.Show, room *waitingRoom, now time.Time) error {
+		if !show.WaitingRoomEnabled {
+			res = openWaitingRoomStatus(showID)
+			return nil
+		}
+		if err := s.advance(ctx, tx, room, now); err != nil {
+			return err
+		}
+
+		if _, admitted := room.admitted[userID]; !admitted && room.position(userID) == 0 {
+			entry, err := tx.CreateWaitingRoomEntry(ctx, &model.WaitingRoomEntry{
+				ShowID:     showID,
+				UserID:     userID,
+				Status:     string(constants.WaitingRoomStateQueued),
+				LastSeenAt: now,
+			})
+			if err != nil {
+				return err
+			}
+			room.queue = append(room.queue, entry)
+			if err := s.advance(ctx, tx, room, now); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		res, err = s.status(ctx, tx, showID, room, userID, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetWaitingRoomStatus reports the user's position and estimated wait, or their admission token once admitted.
+// Polling keeps the user's place; users who stop polling for WAITING_ROOM_IDLE_TIMEOUT lose it.
+func (s *waitingRoomService) GetWaitingRoomStatus(ctx context.Context, showID uint, userID uint) (*types.WaitingRoomStatusResponse, error) {
+	var res *types.WaitingRoomStatusResponse
+	err := s.withWaitingRoom(ctx, showID, func(tx model.DataStore, show *model.Show, room *waitingRoom, now time.Time) error {
+		if !show.WaitingRoomEnabled {
+			res = openWaitingRoomStatus(showID)
+			return nil
+		}
+		if err := s.advance(ctx, tx, room, now); err != nil {
+			return err
+		}
+		if room.admitted[userID] == nil && room.position(userID) == 0 {
+			return errors.NewHTTPErrorWithCode(http.StatusNotFound, constants.ErrCodeNotInWaitingRoom,
+				"you are not in the waiting room for this show; join the queue first")
+		}
+
+		var err error
+		res, err = s.status(ctx, tx, showID, room, userID, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LeaveWaitingRoom removes the user from the queue or ends their admission, freeing the slot for the next user
+func (s *waitingRoomService) LeaveWaitingRoom(ctx context.Context, showID uint, userID uint) error {
+	return s.withWaitingRoom(ctx, showID, func(tx model.DataStore, show *model.Show, room *waitingRoom, now time.Time) error {
+		if entry, ok := room.admitted[userID]; ok {
+			if err := tx.DeleteWaitingRoomEntries(ctx, []uint{entry.ID}); err != nil {
+				return err
+			}
+			delete(room.admitted, userID)
+		}
+		if pos := room.position(userID); pos > 0 {
+			if err := tx.DeleteWaitingRoomEntries(ctx, []uint{room.queue[pos-1].ID}); err != nil {
+				return err
+			}
+			room.queue = append(room.queue[:pos-1], room.queue[pos:]...)
+		}
+		if !show.WaitingRoomEnabled {
+			return nil
+		}
+		return s.advance(ctx, tx, room, now)
+	})
+}
+
+// CheckAdmission returns nil if the show has no waiting room, or if token is the user's live admission for it
+func (s *waitingRoomService) CheckAdmission(ctx context.Context, showID uint, userID uint, token string) error {
+	show, err := s.store.GetShowByID(ctx, showID)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusNotFound, "show not found")
+	}
+	if !show.WaitingRoomEnabled {
+		return nil
+	}
+
+	if token == "" {
+		return errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeAdmissionRequired,
+			"this show has a waiting room; join the queue and send your admission token")
+	}
+
+	claims := &admissionClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(config.GetJWTSecret()), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(constants.AdmissionTokenAudience)); err != nil {
+		return errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeAdmissionRequired,
+			"admission token is invalid or has expired; join the queue again")
+	}
+	if claims.ShowID != showID || claims.Subject != strconv.FormatUint(uint64(userID), 10) {
+		return errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeAdmissionRequired,
+			"admission token was issued for another show or user")
+	}
+
+	// The token alone is not enough: the admission must still hold one of the show's slots
+	entry, err := s.store.GetWaitingRoomEntry(ctx, showID, userID)
+	if err != nil {
+		return err
+	}
+	if entry == nil || !isLiveAdmission(entry, time.Now()) || strconv.FormatUint(uint64(entry.ID), 10) != claims.ID {
+		return errors.NewHTTPErrorWithCode(http.StatusForbidden, constants.ErrCodeAdmissionRequired,
+			"your admission has ended; join the queue again")
+	}
+	return nil
+}
+
+// CheckSeatAdmission is CheckAdmission for the show the seat belongs to
+func (s *waitingRoomService) CheckSeatAdmission(ctx context.Context, seatID uint, userID uint, token string) error {
+	seat, err := s.store.GetSeatByID(ctx, seatID)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusNotFound, "seat not found")
+	}
+	return s.CheckAdmission(ctx, seat.ShowID, userID, token)
+}
+
+// withWaitingRoom runs fn in a transaction holding the show row lock, with the show's room as stored
+func (s *waitingRoomService) withWaitingRoom(ctx context.Context, showID uint,
+	fn func(tx model.DataStore, show *model.Show, room *waitingRoom, now time.Time) error) error {
+	tx, err := s.store.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback on panic or error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback(ctx)
+			panic(r)
+		}
+	}()
+
+	// Step 1: Lock the show row so replicas change its room one at a time
+	show, err := tx.GetShowByIDForUpdate(ctx, showID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errors.NewHTTPError(http.StatusNotFound, "show not found")
+	}
+
+	// Step 2: Load the room
+	entries, err := tx.GetWaitingRoomEntriesByShow(ctx, showID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	room := &waitingRoom{admitted: make(map[uint]*model.WaitingRoomEntry)}
+	for i := range entries {
+		if entries[i].Status == string(constants.WaitingRoomStateAdmitted) {
+			room.admitted[entries[i].UserID] = &entries[i]
+			continue
+		}
+		room.queue = append(room.queue, &entries[i])
+	}
+
+	// Step 3: Apply the change
+	if err := fn(tx, show, room, time.Now()); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// advance drops ended admissions and idle queue entries, then admits users from the front of the queue
+// while slots are free
+func (s *waitingRoomService) advance(ctx context.Context, tx model.DataStore, room *waitingRoom, now time.Time) error {
+	var ended []uint
+	for userID, entry := range room.admitted {
+		if !isLiveAdmission(entry, now) {
+			ended = append(ended, entry.ID)
+			delete(room.admitted, userID)
+		}
+	}
+
+	idleTimeout := config.GetWaitingRoomIdleTimeout()
+	queue := room.queue[:0]
+	for _, entry := range room.queue {
+		if now.Sub(entry.LastSeenAt) <= idleTimeout {
+			queue = append(queue, entry)
+			continue
+		}
+		ended = append(ended, entry.ID)
+	}
+	room.queue = queue
+
+	if err := tx.DeleteWaitingRoomEntries(ctx, ended); err != nil {
+		return err
+	}
+
+	maxAdmitted := config.GetWaitingRoomMaxAdmitted()
+	for len(room.queue) > 0 && len(room.admitted) < maxAdmitted {
+		entry := room.queue[0]
+		admittedUntil := now.Add(config.GetWaitingRoomAdmissionTTL())
+		if err := tx.UpdateWaitingRoomEntry(ctx, entry.ID, map[string]interface{}{
+			"status":         string(constants.WaitingRoomStateAdmitted),
+			"admitted_until": admittedUntil,
+		}); err != nil {
+			return err
+		}
+		entry.Status = string(constants.WaitingRoomStateAdmitted)
+		entry.AdmittedUntil = &admittedUntil
+		room.queue = room.queue[1:]
+		room.admitted[entry.UserID] = entry
+	}
+	return nil
+}
+
+// status describes the user's place in the room, signing an admission token if they are admitted.
+// A queued user's poll is recorded so they keep their place.
+func (s *waitingRoomService) status(ctx context.Context, tx model.DataStore, showID uint, room *waitingRoom, userID uint, now time.Time) (*types.WaitingRoomStatusResponse, error) {
+	res := &types.WaitingRoomStatusResponse{
+		ShowID:      showID,
+		QueueLength: len(room.queue),
+	}
+
+	if entry, ok := room.admitted[userID]; ok {
+		token, err := signAdmissionToken(showID, userID, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign admission token: %w", err)
+		}
+		expiresAt := *entry.AdmittedUntil
+		res.State = string(constants.WaitingRoomStateAdmitted)
+		res.AdmissionToken = token
+		res.AdmittedUntil = &expiresAt
+		return res, nil
+	}
+
+	pos := room.position(userID)
+	entry := room.queue[pos-1]
+	if err := tx.UpdateWaitingRoomEntry(ctx, entry.ID, map[string]interface{}{"last_seen_at": now}); err != nil {
+		return nil, err
+	}
+	entry.LastSeenAt = now
+	res.State = string(constants.WaitingRoomStateQueued)
+	res.Position = pos
+	res.EstimatedWaitSeconds = int(room.estimatedWait(pos, now, config.GetWaitingRoomAdmissionTTL()).Seconds())
+	return res, nil
+}
+
+// isLiveAdmission reports whether the entry is admitted and its admission has not run out
+func isLiveAdmission(entry *model.WaitingRoomEntry, now time.Time) bool {
+	return entry.Status == string(constants.WaitingRoomStateAdmitted) && entry.AdmittedUntil != nil &&
+		now.Before(*entry.AdmittedUntil)
+}
+
+// position returns the user's 1-based place in the queue, or 0 if they are not queued
+func (r *waitingRoom) position(userID uint) int {
+	for i, entry := range r.queue {
+		if entry.UserID == userID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// estimatedWait assumes every admitted session runs for its full TTL: the user at pos takes the
+// slot that frees up pos-th, and each further round through the slots adds another TTL
+func (r *waitingRoom) estimatedWait(pos int, now time.Time, admissionTTL time.Duration) time.Duration {
+	if len(r.admitted) == 0 {
+		return 0
+	}
+
+	expiries := make([]time.Time, 0, len(r.admitted))
+	for _, entry := range r.admitted {
+		expiries = append(expiries, *entry.AdmittedUntil)
+	}
+	sort.Slice(expiries, func(i, j int) bool { return expiries[i].Before(expiries[j]) })
+
+	slot := pos - 1
+	wait := expiries[slot%len(expiries)].Sub(now) + time.Duration(slot/len(expiries))*admissionTTL
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// signAdmissionToken issues the token that proves an admission on seat and booking requests; its ID is the entry's
+func signAdmissionToken(showID, userID uint, entry *model.WaitingRoomEntry) (string, error) {
+	claims := admissionClaims{
+		ShowID: showID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        strconv.FormatUint(uint64(entry.ID), 10),
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			Audience:  jwt.ClaimStrings{constants.AdmissionTokenAudience},
+			ExpiresAt: jwt.NewNumericDate(*entry.AdmittedUntil),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.GetJWTSecret()))
+}
+
+// openWaitingRoomStatus is the status of a show without a waiting room
+func openWaitingRoomStatus(showID uint) *types.WaitingRoomStatusResponse {
+	return &types.WaitingRoomStatusResponse{
+		ShowID: showID,
+		State:  string(constants.WaitingRoomStateOpen),
+	}
+}
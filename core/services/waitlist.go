@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"movie-booking/api/v1/types"
+	"movie-booking/config"
+	"movie-booking/constants"
+	"movie-booking/core/model"
+	coretypes "movie-booking/core/types"
+	"movie-booking/util/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// waitlistOfferAdvisoryLock names the MySQL lock that keeps a single replica making waitlist offers at a time
+const waitlistOfferAdvisoryLock = "movie_booking.waitlist_offers"
+
+// waitlistService lets users wait for seats of a sold-out show. The offers themselves are made by the WaitlistOfferer.
+type waitlistService struct {
+	store    model.DataStore
+	seats    *seatService
+	waitlist coretypes.SeatAvailabilityListener
+}
+
+// NewWaitlistService creates a new waitlist service
+func NewWaitlistService(clients *coretypes.Clients, store model.DataStore) WaitlistServiceInterface {
+	return &waitlistService{store: store, seats: newSeatService(clients, store), waitlist: clients.Waitlist}
+}
+
+// JoinWaitlist puts the user at the back of a show's waitlist, or reports their place if already on it
+func (s *waitlistService) JoinWaitlist(ctx context.Context, showID uint, userID uint, seatCount int) (*types.WaitlistStatusResponse, error) {
+	if seatCount > config.GetMaxSeatsPerHold() {
+		return nil, errors.NewHTTPError(http.StatusBadRequest,
+			fmt.Sprintf("at most %d seats can be requested at once", config.GetMaxSeatsPerHold()))
+	}
+
+	show, err := s.store.GetShowByID(ctx, showID)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusNotFound, "show not found")
+	}
+	if !time.Now().Before(salesCloseAt(show)) {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusConflict, constants.ErrCodeSalesClosed,
+			"ticket sales for this show have closed")
+	}
+
+	entry, err := s.store.GetLatestWaitlistEntry(ctx, showID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || !isActiveWaitlistEntry(entry) {
+		entry, err = s.store.CreateWaitlistEntry(ctx, &model.WaitlistEntry{
+			ShowID:    showID,
+			UserID:    userID,
+			SeatCount: seatCount,
+			Status:    string(constants.WaitlistStatusWaiting),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Seats may already be free; let the offerer look right away
+		if s.waitlist != nil {
+			s.waitlist.SeatsFreed(showID)
+		}
+	}
+
+	return s.status(ctx, entry)
+}
+
+// GetWaitlistStatus reports the user's latest entry on a show's waitlist, with the held seats once they have an offer
+func (s *waitlistService) GetWaitlistStatus(ctx context.Context, showID uint, userID uint) (*types.WaitlistStatusResponse, error) {
+	entry, err := s.store.GetLatestWaitlistEntry(ctx, showID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, errors.NewHTTPErrorWithCode(http.StatusNotFound, constants.ErrCodeNotOnWaitlist,
+			"you are not on the waitlist for this show")
+	}
+	return s.status(ctx, entry)
+}
+
+// LeaveWaitlist takes the user off a show's waitlist; an outstanding offer is declined and passes to the next user
+func (s *waitlistService) LeaveWaitlist(ctx context.Context, showID uint, userID uint) error {
+	entry, err := s.store.GetLatestWaitlistEntry(ctx, showID, userID)
+	if err != nil {
+		return err
+	}
+	if entry == nil || !isActiveWaitlistEntry(entry) {
+		return nil
+	}
+
+	left, err := s.store.UpdateWaitlistEntryIfStatus(ctx, entry.ID, entry.Status, map[string]interface{}{
+		"status": string(constants.WaitlistStatusLeft),
+	})
+	if err != nil {
+		return err
+	}
+	if !left || entry.Status != string(constants.WaitlistStatusOffered) {
+		return nil
+	}
+
+	// Give the offered seats back; the release hands them to the next user in line
+	if _, err := s.seats.ReleaseHold(ctx, *entry.HoldID, userID, false); err != nil {
+		if _, ok := errors.IsHTTPError(err); !ok {
+			return err
+		}
+		// The hold already lapsed or was booked from
+	}
+	return nil
+}
+
+// status describes a waitlist entry from its user's point of view
+func (s *waitlistService) status(ctx context.Context, entry *model.WaitlistEntry) (*types.WaitlistStatusResponse, error) {
+	entries, err := s.store.GetActiveWaitlistEntriesByShow(ctx, entry.ShowID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.WaitlistStatusResponse{
+		ShowID:    entry.ShowID,
+		Status:    entry.Status,
+		SeatCount: entry.SeatCount,
+	}
+	for _, e := range entries {
+		if e.Status != string(constants.WaitlistStatusWaiting) {
+			continue
+		}
+		res.QueueLength++
+		if e.ID == entry.ID {
+			res.Position = res.QueueLength
+		}
+	}
+
+	if entry.Status == string(constants.WaitlistStatusOffered) && entry.HoldID != nil {
+		res.HoldID = *entry.HoldID
+		res.OfferExpiresAt = entry.OfferExpiresAt
+
+		// The hold may have been renewed since the offer was made
+		locks, err := s.seats.locks.Inspect(ctx, s.store, coretypes.SeatLockFilter{HoldID: *entry.HoldID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect seat lock: %w", err)
+		}
+		if len(locks) > 0 {
+			res.SeatIDs = locks[0].SeatIDs()
+			res.OfferExpiresAt = &locks[0].ExpiresAt
+		}
+	}
+	return res, nil
+}
+
+// isActiveWaitlistEntry reports whether the entry is still WAITING or OFFERED
+func isActiveWaitlistEntry(entry *model.WaitlistEntry) bool {
+	for _, status := range constants.ActiveWaitlistStatuses {
+		if entry.Status == string(status) {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitlistOfferer offers freed seats to waitlisted users in order. Each offer is an ordinary seat hold taken
+// for the user, so it lasts SEAT_LOCK_DURATION and is booked like any other hold. When an offer's hold lapses
+// or is released without a booking, its seats are freed again and offered to the next user in line.
+type WaitlistOfferer struct {
+	store    model.DataStore
+	seats    *seatService
+	notifier coretypes.Notifier
+	freed    chan uint
+}
+
+// NewWaitlistOfferer creates a new waitlist offerer; set it as clients.Waitlist before creating the other services
+func NewWaitlistOfferer(clients *coretypes.Clients, store model.DataStore) *WaitlistOfferer {
+	return &WaitlistOfferer{
+		store:    store,
+		seats:    newSeatService(clients, store),
+		notifier: notifier(clients),
+		freed:    make(chan uint, 256),
+	}
+}
+
+// SeatsFreed queues the show for an offer round; when the queue is full the next periodic round picks it up
+func (o *WaitlistOfferer) SeatsFreed(showID uint) {
+	select {
+	case o.freed <- showID:
+	default:
+	}
+}
+
+// Start makes offers in the background until ctx is cancelled, whenever seats are freed and every WAITLIST_OFFER_INTERVAL
+func (o *WaitlistOfferer) Start(ctx context.Context) {
+	interval := config.GetWaitlistOfferInterval()
+	logrus.WithField("interval", interval.String()).Info("Starting waitlist offerer")
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logrus.Info("Waitlist offerer stopped")
+				return
+			case showID := <-o.freed:
+				if err := o.OfferShows(ctx, o.drainFreed(showID)); err != nil {
+					logrus.WithError(err).Error("Waitlist offer round failed")
+				}
+			case <-ticker.C:
+				// Catches lapsed offers and in-memory holds, which expire without notice
+				showIDs, err := o.store.GetActiveWaitlistShowIDs(ctx)
+				if err != nil {
+					logrus.WithError(err).Error("Waitlist offer round failed")
+					continue
+				}
+				if err := o.OfferShows(ctx, showIDs); err != nil {
+					logrus.WithError(err).Error("Waitlist offer round failed")
+				}
+			}
+		}
+	}()
+}
+
+// drainFreed collects the shows queued so far, so a burst of releases becomes one round per show
+func (o *WaitlistOfferer) drainFreed(showID uint) []uint {
+	showIDs := []uint{showID}
+	seen := map[uint]bool{showID: true}
+	for {
+		select {
+		case id := <-o.freed:
+			if !seen[id] {
+				seen[id] = true
+				showIDs = append(showIDs, id)
+			}
+		default:
+			return showIDs
+		}
+	}
+}
+
+// OfferShows settles lapsed offers and offers free seats for each show, provided no other replica is doing so
+func (o *WaitlistOfferer) OfferShows(ctx context.Context, showIDs []uint) error {
+	if len(showIDs) == 0 {
+		return nil
+	}
+
+	release, acquired, err := o.store.TryAdvisoryLock(ctx, waitlistOfferAdvisoryLock)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		logrus.Debug("Waitlist offer round skipped, another instance holds the offer lock")
+		return nil
+	}
+	defer release()
+
+	for _, showID := range showIDs {
+		if err := o.offerShow(ctx, showID); err != nil {
+			logrus.WithError(err).WithField("showID", showID).Error("Failed to make waitlist offers")
+		}
+	}
+	return nil
+}
+
+// offerShow expires the show's lapsed offers, then offers free seats to waiting users in order.
+// A user whose seat count does not fit keeps their place while smaller requests behind them are served.
+func (o *WaitlistOfferer) offerShow(ctx context.Context, showID uint) error {
+	entries, err := o.store.GetActiveWaitlistEntriesByShow(ctx, showID)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Step 1: An offer whose hold is gone without a booking lapsed or was declined
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Status != string(constants.WaitlistStatusOffered) {
+			continue
+		}
+		if err := o.expireLapsedOffer(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	// Step 2: Offer only while tickets are on sale
+	show, err := o.store.GetShowByID(ctx, showID)
+	if err != nil {
+		return fmt.Errorf("failed to get show: %w", err)
+	}
+	now := time.Now()
+	if !now.Before(salesCloseAt(show)) {
+		return o.closeWaitlist(ctx, entries)
+	}
+	if err := checkSalesWindow(show, now); err != nil {
+		return nil
+	}
+	scorer := seatScorerForShow(show)
+	lockDuration := config.GetSeatLockDuration()
+
+	seats, err := o.seats.seatsWithLocks(ctx, o.store, showID)
+	if err != nil {
+		return err
+	}
+
+	// Step 3: Hold the best block of free seats for each waiting user in turn
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Status != string(constants.WaitlistStatusWaiting) {
+			continue
+		}
+
+		block := findBestSeatBlock(seats, entry.SeatCount, "", show.Theatre.PreventSingleSeatGaps, scorer, now, lockDuration)
+		if block == nil {
+			continue
+		}
+
+		offered, err := o.offer(ctx, entry, block)
+		if err != nil {
+			return err
+		}
+		if !offered {
+			continue
+		}
+
+		if seats, err = o.seats.seatsWithLocks(ctx, o.store, showID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expireLapsedOffer marks an OFFERED entry EXPIRED once its hold is no longer active
+func (o *WaitlistOfferer) expireLapsedOffer(ctx context.Context, entry *model.WaitlistEntry) error {
+	if entry.HoldID != nil {
+		locks, err := o.seats.locks.Inspect(ctx, o.store, coretypes.SeatLockFilter{HoldID: *entry.HoldID})
+		if err != nil {
+			return fmt.Errorf("failed to inspect seat lock: %w", err)
+		}
+		if len(locks) > 0 {
+			return nil
+		}
+	}
+
+	// Booking from the offer marks it CLAIMED first, so only an unclaimed offer changes here
+	expired, err := o.store.UpdateWaitlistEntryIfStatus(ctx, entry.ID, string(constants.WaitlistStatusOffered), map[string]interface{}{
+		"status": string(constants.WaitlistStatusExpired),
+	})
+	if err != nil || !expired {
+		return err
+	}
+	entry.Status = string(constants.WaitlistStatusExpired)
+
+	o.notify(ctx, coretypes.Notification{
+		UserID:  entry.UserID,
+		Type:    constants.NotificationWaitlistOfferExpired,
+		Message: "Your waitlist offer has expired and the seats were passed to the next person in line.",
+		Data:    map[string]interface{}{"show_id": entry.ShowID},
+	})
+	return nil
+}
+
+// closeWaitlist expires the entries still waiting once ticket sales have closed
+func (o *WaitlistOfferer) closeWaitlist(ctx context.Context, entries []model.WaitlistEntry) error {
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Status != string(constants.WaitlistStatusWaiting) {
+			continue
+		}
+		closed, err := o.store.UpdateWaitlistEntryIfStatus(ctx, entry.ID, string(constants.WaitlistStatusWaiting), map[string]interface{}{
+			"status": string(constants.WaitlistStatusExpired),
+		})
+		if err != nil {
+			return err
+		}
+		if closed {
+			o.notify(ctx, coretypes.Notification{
+				UserID:  entry.UserID,
+				Type:    constants.NotificationWaitlistClosed,
+				Message: "Ticket sales for this show have closed, so no seats will be offered from the waitlist.",
+				Data:    map[string]interface{}{"show_id": entry.ShowID},
+			})
+		}
+	}
+	return nil
+}
+
+// offer holds block for the entry's user and records the offer, reporting false if the seats or user could not be served
+func (o *WaitlistOfferer) offer(ctx context.Context, entry *model.WaitlistEntry, block []model.ShowSeat) (bool, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"showID":  entry.ShowID,
+		"userID":  entry.UserID,
+		"entryID": entry.ID,
+	})
+
+	seatIDs := make([]uint, len(block))
+	seatNames := make([]string, len(block))
+	for i, seat := range block {
+		seatIDs[i] = seat.ID
+		seatNames[i] = seat.SeatName
+	}
+
+	// The same checks as a user's own lock apply, quotas included
+	lock, err := o.seats.lockSeats(ctx, entry.ShowID, seatIDs, entry.UserID)
+	if err != nil {
+		if _, ok := errors.IsHTTPError(err); ok {
+			logger.WithError(err).Info("Skipping waitlist entry, seats could not be held")
+			return false, nil
+		}
+		return false, err
+	}
+
+	offered, err := o.store.UpdateWaitlistEntryIfStatus(ctx, entry.ID, string(constants.WaitlistStatusWaiting), map[string]interface{}{
+		"status":           string(constants.WaitlistStatusOffered),
+		"hold_id":          lock.HoldID,
+		"offered_at":       lock.LockedAt,
+		"offer_expires_at": lock.ExpiresAt,
+	})
+	if err != nil || !offered {
+		// The user left in the meantime, or the offer cannot be recorded: give the seats back
+		if _, releaseErr := o.seats.ReleaseHold(ctx, lock.HoldID, entry.UserID, false); releaseErr != nil {
+			logger.WithError(releaseErr).Error("Failed to release unrecorded waitlist hold")
+		}
+		return false, err
+	}
+	entry.Status = string(constants.WaitlistStatusOffered)
+
+	logger.WithField("holdID", lock.HoldID).Info("Offered seats to waitlisted user")
+	o.notify(ctx, coretypes.Notification{
+		UserID: entry.UserID,
+		Type:   constants.NotificationWaitlistOffer,
+		Message: fmt.Sprintf("%d seats are held for you until %s. Book them before the hold expires.",
+			len(seatIDs), lock.ExpiresAt.Format(time.RFC3339)),
+		Data: map[string]interface{}{
+			"show_id":    entry.ShowID,
+			"hold_id":    lock.HoldID,
+			"seat_ids":   seatIDs,
+			"seat_names": seatNames,
+			"expires_at": lock.ExpiresAt,
+		},
+	})
+	return true, nil
+}
+
+// notify delivers a notification, logging rather than failing the round when delivery fails
+func (o *WaitlistOfferer) notify(ctx context.Context, notification coretypes.Notification) {
+	if err := o.notifier.Notify(ctx, notification); err != nil {
+		logrus.WithContext(ctx).WithError(err).WithField("userID", notification.UserID).Error("Failed to send notification")
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"context"
+
+	"movie-booking/constants"
+)
+
+// Notification is a message for one user
+type Notification struct {
+	UserID  uint
+	Type    constants.NotificationType
+	Message string
+	Data    map[string]interface{} // Structured details, e.g. the offered hold
+}
+
+// Notifier delivers notifications to users (email, push, ...)
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// SeatAvailabilityListener is told when seats of a show return to sale
+type SeatAvailabilityListener interface {
+	// SeatsFreed must not block; listeners queue the work
+	SeatsFreed(showID uint)
+}
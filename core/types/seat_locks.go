@@ -0,0 +1,64 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	"movie-booking/core/model"
+)
+
+// SeatLock is an active hold on one or more seats of a show
+type SeatLock struct {
+	HoldID    uint
+	ShowID    uint
+	UserID    uint
+	Seats     []model.ShowSeat
+	LockedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// SeatIDs returns the IDs of the held seats
+func (l *SeatLock) SeatIDs() []uint {
+	ids := make([]uint, len(l.Seats))
+	for i, seat := range l.Seats {
+		ids[i] = seat.ID
+	}
+	return ids
+}
+
+// SeatLockFilter selects the locks returned by SeatLockManager.Inspect; zero fields are ignored
+type SeatLockFilter struct {
+	HoldID uint
+	ShowID uint
+	UserID uint
+	SeatID uint
+}
+
+// SeatLockRelease describes which held seats to free: a whole hold, or individual seats when HoldID is zero
+type SeatLockRelease struct {
+	HoldID  uint
+	SeatIDs []uint
+	UserID  uint
+	IsStaff bool
+	Reason  string // Recorded in the seat history; defaults to who released the seats
+}
+
+// SeatLockManager acquires, renews, releases and inspects seat holds.
+// Every call receives the caller's store: row-lock implementations join its transaction,
+// in-memory implementations apply their change immediately and only read seats through it,
+// and optimistic implementations expect the plain store and commit each compare-and-set on its own.
+type SeatLockManager interface {
+	// UsesTransaction reports whether calls should run inside a caller transaction
+	UsesTransaction() bool
+	// Acquire locks every seat for the user under a new hold, or none of them; seatIDs must be sorted
+	Acquire(ctx context.Context, tx model.DataStore, showID, userID uint, seatIDs []uint) (*SeatLock, error)
+	// Renew extends an unexpired hold, within the configured extension and total-duration limits
+	Renew(ctx context.Context, tx model.DataStore, holdID, userID uint) (*SeatLock, error)
+	// Release frees held seats; only the holder or staff may release them
+	Release(ctx context.Context, tx model.DataStore, req SeatLockRelease) ([]model.ShowSeat, error)
+	// Inspect returns the unexpired locks matching filter, each listing only its matching seats
+	Inspect(ctx context.Context, tx model.DataStore, filter SeatLockFilter) ([]SeatLock, error)
+	// Version counts changes, expiries included, to a show's holds that show_seats does not record;
+	// backends that keep holds in the rows return 0
+	Version(showID uint) uint64
+}
@@ -1,6 +1,14 @@
 package types
 
+import (
+	"movie-booking/core/events"
+)
+
 // Clients aggregates external dependencies for injection
 type Clients struct {
 	// Add external clients here if needed (Kafka, etc.)
+	SeatEvents *events.Hub              // In-process seat status pub/sub, nil disables publishing
+	SeatLocks  SeatLockManager          // Seat hold backend, nil selects the MySQL row-lock manager
+	Notifier   Notifier                 // User notifications, nil only logs them
+	Waitlist   SeatAvailabilityListener // Offers freed seats to waitlisted users, nil disables offers
 }
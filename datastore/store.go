@@ -2,11 +2,15 @@ package datastore
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"movie-booking/constants"
 	"movie-booking/core/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DBStore implements the DataStore interface using GORM
@@ -92,6 +96,21 @@ func (ds *DBStore) GetUserByEmail(ctx context.Context, email string) (*model.Use
 	return &user, nil
 }
 
+// GetUserByIDForUpdate locks the user row using FOR UPDATE
+func (ds *DBStore) GetUserByIDForUpdate(ctx context.Context, id uint) (*model.User, error) {
+	var user model.User
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get user for update: %w", err)
+	}
+	return &user, nil
+}
+
 // MovieStore implementation
 
 func (ds *DBStore) GetAllMovies(ctx context.Context) ([]model.Movie, error) {
@@ -115,13 +134,32 @@ func (ds *DBStore) GetMovieByID(ctx context.Context, id uint) (*model.Movie, err
 
 // ShowStore implementation
 
-func (ds *DBStore) GetShowsByMovieID(ctx context.Context, movieID uint) ([]model.Show, error) {
+func (ds *DBStore) GetShowsByMovieID(ctx context.Context, movieID uint, filter model.ShowFilter) ([]model.Show, error) {
 	var shows []model.Show
-	if err := ds.db.WithContext(ctx).
+	query := ds.db.WithContext(ctx).
 		Preload("Movie").
 		Preload("Theatre").
-		Where("movie_id = ?", movieID).
-		Find(&shows).Error; err != nil {
+		Preload("Screen").
+		Preload("FormatSurcharge").
+		Where("movie_id = ?", movieID)
+
+	if filter.Format != "" {
+		query = query.Where("format = ?", filter.Format)
+	}
+	if filter.AudioLanguage != "" {
+		query = query.Where("audio_language = ?", filter.AudioLanguage)
+	}
+	if filter.SubtitleLanguage != "" {
+		query = query.Where("subtitle_language = ?", filter.SubtitleLanguage)
+	}
+	if filter.Date != nil {
+		// Coarse UTC range covering every zone offset (UTC-12 to UTC+14); the exact
+		// local-date match happens in the service once the theatre zone is known
+		query = query.Where("start_time >= ? AND start_time < ?",
+			filter.Date.Add(-14*time.Hour), filter.Date.Add(36*time.Hour))
+	}
+
+	if err := query.Find(&shows).Error; err != nil {
 		return nil, fmt.Errorf("failed to get shows: %w", err)
 	}
 	return shows, nil
@@ -132,6 +170,8 @@ func (ds *DBStore) GetShowByID(ctx context.Context, id uint) (*model.Show, error
 	if err := ds.db.WithContext(ctx).
 		Preload("Movie").
 		Preload("Theatre").
+		Preload("Screen").
+		Preload("FormatSurcharge").
 		Where("id = ?", id).
 		First(&show).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -142,26 +182,62 @@ func (ds *DBStore) GetShowByID(ctx context.Context, id uint) (*model.Show, error
 	return &show, nil
 }
 
+// GetShowByIDForUpdate locks the show row using FOR UPDATE
+func (ds *DBStore) GetShowByIDForUpdate(ctx context.Context, id uint) (*model.Show, error) {
+	var show model.Show
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&show).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("show not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get show for update: %w", err)
+	}
+	return &show, nil
+}
+
+// SetShowWaitingRoom switches the show's waiting room on or off
+func (ds *DBStore) SetShowWaitingRoom(ctx context.Context, id uint, enabled bool) error {
+	if err := ds.db.WithContext(ctx).
+		Model(&model.Show{}).
+		Where("id = ?", id).
+		Update("waiting_room_enabled", enabled).Error; err != nil {
+		return fmt.Errorf("failed to update show waiting room: %w", err)
+	}
+	return nil
+}
+
 // ShowSeatStore implementation
 
 func (ds *DBStore) GetSeatsByShowID(ctx context.Context, showID uint) ([]model.ShowSeat, error) {
 	var seats []model.ShowSeat
 	if err := ds.db.WithContext(ctx).
 		Where("show_id = ?", showID).
-		Order("seat_name").
+		Order("CHAR_LENGTH(row_label), row_label, seat_number, seat_name").
 		Find(&seats).Error; err != nil {
 		return nil, fmt.Errorf("failed to get seats: %w", err)
 	}
 	return seats, nil
 }
 
+func (ds *DBStore) GetSeatByID(ctx context.Context, id uint) (*model.ShowSeat, error) {
+	var seat model.ShowSeat
+	if err := ds.db.WithContext(ctx).Where("id = ?", id).First(&seat).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("seat not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get seat: %w", err)
+	}
+	return &seat, nil
+}
+
 // GetSeatByIDForUpdate locks the seat row using FOR UPDATE
 func (ds *DBStore) GetSeatByIDForUpdate(ctx context.Context, id uint) (*model.ShowSeat, error) {
 	var seat model.ShowSeat
-	// Use raw SQL with FOR UPDATE for row-level locking
-	// GORM doesn't have a direct Locking clause in v1.26, so we use Set("gorm:query_option", "FOR UPDATE")
+	// GORM v2 ignores the v1 "gorm:query_option" setting, so the locking clause is added explicitly
 	if err := ds.db.WithContext(ctx).
-		Set("gorm:query_option", "FOR UPDATE").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("id = ?", id).
 		First(&seat).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -172,20 +248,151 @@ func (ds *DBStore) GetSeatByIDForUpdate(ctx context.Context, id uint) (*model.Sh
 	return &seat, nil
 }
 
-func (ds *DBStore) UpdateSeat(ctx context.Context, id uint, updates map[string]interface{}) error {
-	result := ds.db.WithContext(ctx).
-		Model(&model.ShowSeat{}).
-		Where("id = ?", id).
-		Updates(updates)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update seat: %w", result.Error)
+func (ds *DBStore) UpdateSeat(ctx context.Context, id uint, updates map[string]interface{}, event *model.SeatEvent) error {
+	affected, err := ds.writeSeat(ctx, event, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.ShowSeat{}).
+			Where("id = ?", id).
+			Updates(withNextSeatVersion(updates))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update seat: %w", err)
 	}
-	if result.RowsAffected == 0 {
+	if affected == 0 {
 		return fmt.Errorf("seat not found or no changes made")
 	}
 	return nil
 }
 
+// UpdateSeatIfVersion applies updates only if the seat is still at version; false means another writer got there first
+func (ds *DBStore) UpdateSeatIfVersion(ctx context.Context, id uint, version uint, updates map[string]interface{}, event *model.SeatEvent) (bool, error) {
+	affected, err := ds.writeSeat(ctx, event, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.ShowSeat{}).
+			Where("id = ? AND version = ?", id, version).
+			Updates(withNextSeatVersion(updates))
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to update seat: %w", err)
+	}
+	return affected == 1, nil
+}
+
+// LockSeatIfAvailable applies updates only if the seat is still at version and free (AVAILABLE, or LOCKED before lockedBefore)
+func (ds *DBStore) LockSeatIfAvailable(ctx context.Context, id uint, version uint, lockedBefore time.Time, updates map[string]interface{}, event *model.SeatEvent) (bool, error) {
+	affected, err := ds.writeSeat(ctx, event, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.ShowSeat{}).
+			Where("id = ? AND version = ? AND (status = ? OR (status = ? AND locked_at < ?))",
+				id, version, string(constants.SeatStatusAvailable), string(constants.SeatStatusLocked), lockedBefore).
+			Updates(withNextSeatVersion(updates))
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to lock seat: %w", err)
+	}
+	return affected == 1, nil
+}
+
+// writeSeat runs a seat update and, if it changed a row, inserts its audit event in the same transaction:
+// the caller's when there is one, otherwise a short one of its own
+func (ds *DBStore) writeSeat(ctx context.Context, event *model.SeatEvent, update func(db *gorm.DB) *gorm.DB) (int64, error) {
+	var affected int64
+	write := func(db *gorm.DB) error {
+		result := update(db)
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		if affected == 0 || event == nil {
+			return nil
+		}
+		if err := db.Create(event).Error; err != nil {
+			return fmt.Errorf("failed to record seat event: %w", err)
+		}
+		return nil
+	}
+
+	if ds.inTransactionMode || event == nil {
+		return affected, write(ds.db.WithContext(ctx))
+	}
+	return affected, ds.db.WithContext(ctx).Transaction(write)
+}
+
+// GetSeatEventsBySeatID returns a seat's audit history, oldest first
+func (ds *DBStore) GetSeatEventsBySeatID(ctx context.Context, seatID uint) ([]model.SeatEvent, error) {
+	var seatEvents []model.SeatEvent
+	if err := ds.db.WithContext(ctx).
+		Where("show_seat_id = ?", seatID).
+		Order("id").
+		Find(&seatEvents).Error; err != nil {
+		return nil, fmt.Errorf("failed to get seat events: %w", err)
+	}
+	return seatEvents, nil
+}
+
+// GetSeatVersionByShowID returns a number that grows whenever the show's seat map as read at that moment changes.
+// Every seat write bumps one seat's version, adding 2; a lock lapsing before lockedBefore adds 1, and the write
+// that later clears or retakes it removes that 1 again while adding 2, so the total never repeats.
+func (ds *DBStore) GetSeatVersionByShowID(ctx context.Context, showID uint, lockedBefore time.Time) (uint64, error) {
+	var version uint64
+	if err := ds.db.WithContext(ctx).
+		Model(&model.ShowSeat{}).
+		Select("COALESCE(2 * SUM(version) + SUM(status = ? AND locked_at < ?), 0)", string(constants.SeatStatusLocked), lockedBefore).
+		Where("show_id = ?", showID).
+		Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to get seat version: %w", err)
+	}
+	return version, nil
+}
+
+// withNextSeatVersion adds the version bump every seat write must carry
+func withNextSeatVersion(updates map[string]interface{}) map[string]interface{} {
+	versioned := make(map[string]interface{}, len(updates)+1)
+	for column, value := range updates {
+		versioned[column] = value
+	}
+	versioned["version"] = gorm.Expr("version + 1")
+	return versioned
+}
+
+// GetUpcomingSeatsByScreenForUpdate locks the named seats of every show on the screen starting after startAfter,
+// in ID order so it cannot deadlock with seat locks
+func (ds *DBStore) GetUpcomingSeatsByScreenForUpdate(ctx context.Context, screenID uint, seatNames []string, startAfter time.Time) ([]model.ShowSeat, error) {
+	var seats []model.ShowSeat
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN shows ON shows.id = show_seats.show_id").
+		Where("shows.screen_id = ? AND shows.start_time > ? AND show_seats.seat_name IN ?", screenID, startAfter, seatNames).
+		Order("show_seats.id").
+		Find(&seats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get screen seats: %w", err)
+	}
+	return seats, nil
+}
+
+// GetExpiredSeatLocksForUpdate locks a batch of seats whose holds have lapsed, skipping rows other transactions hold
+func (ds *DBStore) GetExpiredSeatLocksForUpdate(ctx context.Context, lockedBefore time.Time, limit int) ([]model.ShowSeat, error) {
+	var seats []model.ShowSeat
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND locked_at < ?", string(constants.SeatStatusLocked), lockedBefore).
+		Order("id").
+		Limit(limit).
+		Find(&seats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expired seat locks: %w", err)
+	}
+	return seats, nil
+}
+
+func (ds *DBStore) GetLockedSeatsByUser(ctx context.Context, userID uint, lockedAfter time.Time) ([]model.ShowSeat, error) {
+	var seats []model.ShowSeat
+	if err := ds.db.WithContext(ctx).
+		Where("user_id = ? AND status = ? AND locked_at >= ?",
+			userID, string(constants.SeatStatusLocked), lockedAfter).
+		Order("id").
+		Find(&seats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get locked seats: %w", err)
+	}
+	return seats, nil
+}
+
 func (ds *DBStore) CreateSeat(ctx context.Context, seat *model.ShowSeat) (*model.ShowSeat, error) {
 	if err := ds.db.WithContext(ctx).Create(seat).Error; err != nil {
 		return nil, fmt.Errorf("failed to create seat: %w", err)
@@ -193,26 +400,166 @@ func (ds *DBStore) CreateSeat(ctx context.Context, seat *model.ShowSeat) (*model
 	return seat, nil
 }
 
+// SeatHoldStore implementation
+
+func (ds *DBStore) CreateSeatHold(ctx context.Context, hold *model.SeatHold) (*model.SeatHold, error) {
+	if err := ds.db.WithContext(ctx).Create(hold).Error; err != nil {
+		return nil, fmt.Errorf("failed to create seat hold: %w", err)
+	}
+	return hold, nil
+}
+
+func (ds *DBStore) GetSeatHoldByID(ctx context.Context, id uint) (*model.SeatHold, error) {
+	var hold model.SeatHold
+	if err := ds.db.WithContext(ctx).
+		Preload("Seats", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Where("id = ?", id).
+		First(&hold).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("seat hold not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get seat hold: %w", err)
+	}
+	return &hold, nil
+}
+
+// GetSeatHoldByIDForUpdate locks the hold row using FOR UPDATE
+func (ds *DBStore) GetSeatHoldByIDForUpdate(ctx context.Context, id uint) (*model.SeatHold, error) {
+	var hold model.SeatHold
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Seats", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Where("id = ?", id).
+		First(&hold).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("seat hold not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get seat hold for update: %w", err)
+	}
+	return &hold, nil
+}
+
+func (ds *DBStore) UpdateSeatHold(ctx context.Context, id uint, updates map[string]interface{}) error {
+	result := ds.db.WithContext(ctx).
+		Model(&model.SeatHold{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update seat hold: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("seat hold not found or no changes made")
+	}
+	return nil
+}
+
+func (ds *DBStore) DeleteSeatHold(ctx context.Context, id uint) error {
+	if err := ds.db.WithContext(ctx).Where("id = ?", id).Delete(&model.SeatHold{}).Error; err != nil {
+		return fmt.Errorf("failed to delete seat hold: %w", err)
+	}
+	return nil
+}
+
 // BookingStore implementation
 
-func (ds *DBStore) CreateBooking(ctx context.Context, booking *model.Booking) (*model.Booking, error) {
-	if err := ds.db.WithContext(ctx).Create(booking).Error; err != nil {
-		return nil, fmt.Errorf("failed to create booking: %w", err)
+func (ds *DBStore) CreateOrder(ctx context.Context, order *model.Order) (*model.Order, error) {
+	if err := ds.db.WithContext(ctx).Create(order).Error; err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
-	return booking, nil
+	return order, nil
 }
 
-func (ds *DBStore) GetBookingByUserAndIdempotencyKey(ctx context.Context, userID uint, idempotencyKey string) (*model.Booking, error) {
-	var booking model.Booking
+func (ds *DBStore) GetOrderByUserAndIdempotencyKey(ctx context.Context, userID uint, idempotencyKey string) (*model.Order, error) {
+	var order model.Order
 	if err := ds.db.WithContext(ctx).
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Bookings.Seat").
 		Where("user_id = ? AND idempotency_key = ?", userID, idempotencyKey).
-		First(&booking).Error; err != nil {
+		First(&order).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // Not found is OK for idempotency check
 		}
-		return nil, fmt.Errorf("failed to get booking: %w", err)
+		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
-	return &booking, nil
+	return &order, nil
+}
+
+func (ds *DBStore) UpdateOrder(ctx context.Context, id uint, updates map[string]interface{}) error {
+	result := ds.db.WithContext(ctx).
+		Model(&model.Order{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update order: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("order not found or no changes made")
+	}
+	return nil
+}
+
+// GetOrderByIDForUpdate locks the order row using FOR UPDATE
+func (ds *DBStore) GetOrderByIDForUpdate(ctx context.Context, id uint) (*model.Order, error) {
+	var order model.Order
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("order not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get order for update: %w", err)
+	}
+	return &order, nil
+}
+
+// GetOrderByBookingIDForUpdate locks the order a booking belongs to. The subquery is a plain read, so the booking
+// row is left for the caller to lock after the order, the order every booking status change takes them in.
+func (ds *DBStore) GetOrderByBookingIDForUpdate(ctx context.Context, bookingID uint) (*model.Order, error) {
+	var order model.Order
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = (?)", ds.db.Model(&model.Booking{}).Select("order_id").Where("id = ?", bookingID)).
+		First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("order not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get order for update: %w", err)
+	}
+	return &order, nil
+}
+
+// GetExpiredPendingOrdersForUpdate locks a batch of orders still awaiting payment that were placed before
+// createdBefore, skipping rows other transactions hold
+func (ds *DBStore) GetExpiredPendingOrdersForUpdate(ctx context.Context, createdBefore time.Time, limit int) ([]model.Order, error) {
+	var orders []model.Order
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND created_at < ?", string(constants.BookingStatusPendingPayment), createdBefore).
+		Order("id").
+		Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expired pending orders: %w", err)
+	}
+	return orders, nil
+}
+
+// GetBookingsByOrderIDForUpdate locks the order's bookings using FOR UPDATE, in ID order
+func (ds *DBStore) GetBookingsByOrderIDForUpdate(ctx context.Context, orderID uint) ([]model.Booking, error) {
+	var bookings []model.Booking
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Seat").
+		Where("order_id = ?", orderID).
+		Order("id").
+		Find(&bookings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get order bookings: %w", err)
+	}
+	return bookings, nil
 }
 
 func (ds *DBStore) GetBookingByID(ctx context.Context, id uint) (*model.Booking, error) {
@@ -220,7 +567,11 @@ func (ds *DBStore) GetBookingByID(ctx context.Context, id uint) (*model.Booking,
 	if err := ds.db.WithContext(ctx).
 		Preload("User").
 		Preload("Show").
+		Preload("Show.Movie").
+		Preload("Show.Theatre").
+		Preload("Show.Screen").
 		Preload("Seat").
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
 		Where("id = ?", id).
 		First(&booking).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -230,3 +581,273 @@ func (ds *DBStore) GetBookingByID(ctx context.Context, id uint) (*model.Booking,
 	}
 	return &booking, nil
 }
+
+func (ds *DBStore) GetBookingByIDForUpdate(ctx context.Context, id uint) (*model.Booking, error) {
+	var booking model.Booking
+	if err := ds.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&booking).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("booking not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	return &booking, nil
+}
+
+func (ds *DBStore) UpdateBooking(ctx context.Context, id uint, updates map[string]interface{}, event *model.BookingEvent) error {
+	write := func(db *gorm.DB) error {
+		result := db.Model(&model.Booking{}).
+			Where("id = ?", id).
+			Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update booking: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("booking not found or no changes made")
+		}
+		if event == nil {
+			return nil
+		}
+		if err := db.Create(event).Error; err != nil {
+			return fmt.Errorf("failed to record booking event: %w", err)
+		}
+		return nil
+	}
+
+	if ds.inTransactionMode || event == nil {
+		return write(ds.db.WithContext(ctx))
+	}
+	return ds.db.WithContext(ctx).Transaction(write)
+}
+
+func (ds *DBStore) CreateBookingEvents(ctx context.Context, events []model.BookingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := ds.db.WithContext(ctx).Create(&events).Error; err != nil {
+		return fmt.Errorf("failed to record booking events: %w", err)
+	}
+	return nil
+}
+
+func (ds *DBStore) CountBookingsByUserAndShow(ctx context.Context, userID, showID uint) (int64, error) {
+	var count int64
+	if err := ds.db.WithContext(ctx).
+		Model(&model.Booking{}).
+		Where("user_id = ? AND show_id = ? AND status NOT IN ?", userID, showID, constants.InactiveBookingStatuses).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count bookings: %w", err)
+	}
+	return count, nil
+}
+
+// GetBookingsByUser returns a page of the user's bookings with show, movie, theatre, screen and seat preloaded,
+// one query per relation rather than per booking
+func (ds *DBStore) GetBookingsByUser(ctx context.Context, filter model.BookingFilter) ([]model.Booking, error) {
+	query := ds.db.WithContext(ctx).
+		Joins("JOIN shows ON shows.id = bookings.show_id").
+		Preload("Show").
+		Preload("Show.Movie").
+		Preload("Show.Theatre").
+		Preload("Show.Screen").
+		Preload("Seat").
+		Where("bookings.user_id = ?", filter.UserID)
+
+	switch filter.Period {
+	case string(constants.BookingPeriodUpcoming):
+		query = query.Where("shows.start_time > ?", filter.Now)
+	case string(constants.BookingPeriodPast):
+		query = query.Where("shows.start_time <= ?", filter.Now)
+	}
+
+	// Keyset pagination: continue strictly after the cursor in the listing order
+	if filter.Period == string(constants.BookingPeriodUpcoming) {
+		if filter.After != nil {
+			query = query.Where("(shows.start_time > ? OR (shows.start_time = ? AND bookings.id > ?))",
+				filter.After.StartTime, filter.After.StartTime, filter.After.ID)
+		}
+		query = query.Order("shows.start_time ASC, bookings.id ASC")
+	} else {
+		if filter.After != nil {
+			query = query.Where("(shows.start_time < ? OR (shows.start_time = ? AND bookings.id < ?))",
+				filter.After.StartTime, filter.After.StartTime, filter.After.ID)
+		}
+		query = query.Order("shows.start_time DESC, bookings.id DESC")
+	}
+
+	var bookings []model.Booking
+	if err := query.Limit(filter.Limit).Find(&bookings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+	return bookings, nil
+}
+
+// WaitlistStore implementation
+
+func (ds *DBStore) CreateWaitlistEntry(ctx context.Context, entry *model.WaitlistEntry) (*model.WaitlistEntry, error) {
+	if err := ds.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+	return entry, nil
+}
+
+// GetLatestWaitlistEntry returns the user's most recent waitlist entry for a show in any status, or nil if they never joined
+func (ds *DBStore) GetLatestWaitlistEntry(ctx context.Context, showID, userID uint) (*model.WaitlistEntry, error) {
+	var entry model.WaitlistEntry
+	if err := ds.db.WithContext(ctx).
+		Where("show_id = ? AND user_id = ?", showID, userID).
+		Order("id DESC").
+		First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Never on the waitlist
+		}
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// GetActiveWaitlistEntriesByShow returns a show's WAITING and OFFERED entries in waitlist order
+func (ds *DBStore) GetActiveWaitlistEntriesByShow(ctx context.Context, showID uint) ([]model.WaitlistEntry, error) {
+	var entries []model.WaitlistEntry
+	if err := ds.db.WithContext(ctx).
+		Where("show_id = ? AND status IN ?", showID, constants.ActiveWaitlistStatuses).
+		Order("id").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get waitlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetActiveWaitlistShowIDs returns the shows that have anyone WAITING or OFFERED on their waitlist
+func (ds *DBStore) GetActiveWaitlistShowIDs(ctx context.Context) ([]uint, error) {
+	var showIDs []uint
+	if err := ds.db.WithContext(ctx).
+		Model(&model.WaitlistEntry{}).
+		Where("status IN ?", constants.ActiveWaitlistStatuses).
+		Distinct().
+		Order("show_id").
+		Pluck("show_id", &showIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get waitlisted shows: %w", err)
+	}
+	return showIDs, nil
+}
+
+// UpdateWaitlistEntryIfStatus applies updates only while the entry is still in status, reporting whether it did
+func (ds *DBStore) UpdateWaitlistEntryIfStatus(ctx context.Context, id uint, status string, updates map[string]interface{}) (bool, error) {
+	result := ds.db.WithContext(ctx).
+		Model(&model.WaitlistEntry{}).
+		Where("id = ? AND status = ?", id, status).
+		Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update waitlist entry: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ClaimWaitlistOffer marks the OFFERED entry holding holdID as CLAIMED; holds that are not waitlist offers are ignored
+func (ds *DBStore) ClaimWaitlistOffer(ctx context.Context, holdID uint) error {
+	if err := ds.db.WithContext(ctx).
+		Model(&model.WaitlistEntry{}).
+		Where("hold_id = ? AND status = ?", holdID, constants.WaitlistStatusOffered).
+		Update("status", string(constants.WaitlistStatusClaimed)).Error; err != nil {
+		return fmt.Errorf("failed to claim waitlist offer: %w", err)
+	}
+	return nil
+}
+
+// WaitingRoomStore implementation
+
+func (ds *DBStore) CreateWaitingRoomEntry(ctx context.Context, entry *model.WaitingRoomEntry) (*model.WaitingRoomEntry, error) {
+	if err := ds.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to create waiting room entry: %w", err)
+	}
+	return entry, nil
+}
+
+// GetWaitingRoomEntry returns the user's entry in a show's waiting room, or nil if they are not in it
+func (ds *DBStore) GetWaitingRoomEntry(ctx context.Context, showID, userID uint) (*model.WaitingRoomEntry, error) {
+	var entry model.WaitingRoomEntry
+	if err := ds.db.WithContext(ctx).
+		Where("show_id = ? AND user_id = ?", showID, userID).
+		First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Not in the waiting room
+		}
+		return nil, fmt.Errorf("failed to get waiting room entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// GetWaitingRoomEntriesByShow returns a show's queued and admitted entries in queue order
+func (ds *DBStore) GetWaitingRoomEntriesByShow(ctx context.Context, showID uint) ([]model.WaitingRoomEntry, error) {
+	var entries []model.WaitingRoomEntry
+	if err := ds.db.WithContext(ctx).
+		Where("show_id = ?", showID).
+		Order("id").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get waiting room entries: %w", err)
+	}
+	return entries, nil
+}
+
+// UpdateWaitingRoomEntry applies updates; a poll within the same second changes nothing, which is not an error
+func (ds *DBStore) UpdateWaitingRoomEntry(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if err := ds.db.WithContext(ctx).
+		Model(&model.WaitingRoomEntry{}).
+		Where("id = ?", id).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update waiting room entry: %w", err)
+	}
+	return nil
+}
+
+func (ds *DBStore) DeleteWaitingRoomEntries(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := ds.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.WaitingRoomEntry{}).Error; err != nil {
+		return fmt.Errorf("failed to delete waiting room entries: %w", err)
+	}
+	return nil
+}
+
+func (ds *DBStore) DeleteWaitingRoomEntriesByShow(ctx context.Context, showID uint) error {
+	if err := ds.db.WithContext(ctx).Where("show_id = ?", showID).Delete(&model.WaitingRoomEntry{}).Error; err != nil {
+		return fmt.Errorf("failed to delete waiting room entries: %w", err)
+	}
+	return nil
+}
+
+// AdvisoryLockStore implementation
+
+// TryAdvisoryLock takes a MySQL GET_LOCK on a dedicated connection, since the lock belongs to the session
+func (ds *DBStore) TryAdvisoryLock(ctx context.Context, name string) (func(), bool, error) {
+	sqlDB, err := ds.db.DB()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection for advisory lock: %w", err)
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		// Closing the connection would also drop the lock; release explicitly so it is freed immediately
+		conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", name)
+		conn.Close()
+	}
+	return release, true, nil
+}
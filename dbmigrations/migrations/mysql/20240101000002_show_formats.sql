@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS format_surcharges (
+    format VARCHAR(20) PRIMARY KEY,
+    surcharge DECIMAL(10,2) NOT NULL DEFAULT 0.00,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO format_surcharges (format, surcharge) VALUES
+    ('STANDARD', 0.00),
+    ('IMAX', 5.00),
+    ('3D', 3.00),
+    ('4DX', 7.00),
+    ('DOLBY', 4.00);
+
+ALTER TABLE shows
+    ADD COLUMN format VARCHAR(20) NOT NULL DEFAULT 'STANDARD' AFTER start_time,
+    ADD COLUMN audio_language VARCHAR(50) NOT NULL DEFAULT 'en' AFTER format,
+    ADD COLUMN subtitle_language VARCHAR(50) NULL AFTER audio_language,
+    ADD COLUMN base_price DECIMAL(10,2) NOT NULL DEFAULT 0.00 AFTER subtitle_language,
+    ADD INDEX idx_format (format),
+    ADD CONSTRAINT fk_shows_format FOREIGN KEY (format) REFERENCES format_surcharges(format);
+
+ALTER TABLE bookings
+    ADD COLUMN amount DECIMAL(10,2) NOT NULL DEFAULT 0.00 AFTER seat_id;
+
+-- +goose Down
+ALTER TABLE bookings DROP COLUMN amount;
+
+ALTER TABLE shows
+    DROP FOREIGN KEY fk_shows_format,
+    DROP INDEX idx_format,
+    DROP COLUMN base_price,
+    DROP COLUMN subtitle_language,
+    DROP COLUMN audio_language,
+    DROP COLUMN format;
+
+DROP TABLE IF EXISTS format_surcharges;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE shows
+    ADD COLUMN sales_open_at TIMESTAMP NULL AFTER base_price,
+    ADD COLUMN sales_close_at TIMESTAMP NULL AFTER sales_open_at;
+
+-- +goose Down
+ALTER TABLE shows
+    DROP COLUMN sales_close_at,
+    DROP COLUMN sales_open_at;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE theatres
+    ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC' AFTER location;
+
+-- +goose Down
+ALTER TABLE theatres DROP COLUMN time_zone;
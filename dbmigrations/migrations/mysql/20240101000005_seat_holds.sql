@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS seat_holds (
+    id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    show_id INT UNSIGNED NOT NULL,
+    user_id INT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_show_id (show_id),
+    INDEX idx_user_id (user_id),
+    FOREIGN KEY (show_id) REFERENCES shows(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE show_seats
+    ADD COLUMN hold_id INT UNSIGNED NULL AFTER user_id,
+    ADD INDEX idx_hold_id (hold_id),
+    ADD CONSTRAINT fk_show_seats_hold FOREIGN KEY (hold_id) REFERENCES seat_holds(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE show_seats
+    DROP FOREIGN KEY fk_show_seats_hold,
+    DROP INDEX idx_hold_id,
+    DROP COLUMN hold_id;
+
+DROP TABLE IF EXISTS seat_holds;
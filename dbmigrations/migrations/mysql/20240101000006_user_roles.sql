@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'CUSTOMER' AFTER name;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN role;
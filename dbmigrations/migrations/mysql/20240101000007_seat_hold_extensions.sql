@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE seat_holds
+    ADD COLUMN extensions INT NOT NULL DEFAULT 0 AFTER user_id;
+
+-- +goose Down
+ALTER TABLE seat_holds DROP COLUMN extensions;
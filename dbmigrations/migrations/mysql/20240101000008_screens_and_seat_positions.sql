@@ -0,0 +1,55 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS screens (
+    id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    theatre_id INT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    layout VARCHAR(50) NOT NULL DEFAULT 'STANDARD',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_theatre_id (theatre_id),
+    FOREIGN KEY (theatre_id) REFERENCES theatres(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE shows
+    ADD COLUMN screen_id INT UNSIGNED NULL AFTER theatre_id,
+    ADD INDEX idx_screen_id (screen_id),
+    ADD CONSTRAINT fk_shows_screen FOREIGN KEY (screen_id) REFERENCES screens(id) ON DELETE SET NULL;
+
+ALTER TABLE show_seats
+    ADD COLUMN row_label VARCHAR(5) NOT NULL DEFAULT '' AFTER seat_name,
+    ADD COLUMN seat_number INT NOT NULL DEFAULT 0 AFTER row_label,
+    ADD COLUMN category VARCHAR(20) NOT NULL DEFAULT 'STANDARD' AFTER seat_number,
+    ADD INDEX idx_show_row (show_id, row_label, seat_number);
+
+-- Backfill positions from seat names such as "A10"
+UPDATE show_seats
+SET row_label = REGEXP_REPLACE(seat_name, '[0-9]+$', ''),
+    seat_number = COALESCE(CAST(REGEXP_SUBSTR(seat_name, '[0-9]+$') AS UNSIGNED), 0);
+
+-- Seats named without a number, such as "BOX", are numbered in ID order after the numbered seats of their row, so
+-- seat-gap checks and best-available searches still see them
+UPDATE show_seats s
+JOIN (
+    SELECT u.id,
+           ROW_NUMBER() OVER (PARTITION BY u.show_id, u.row_label ORDER BY u.id)
+               + (SELECT COALESCE(MAX(n.seat_number), 0)
+                  FROM show_seats n
+                  WHERE n.show_id = u.show_id AND n.row_label = u.row_label) AS position
+    FROM show_seats u
+    WHERE u.seat_name NOT REGEXP '[0-9]+$'
+) unnumbered ON unnumbered.id = s.id
+SET s.seat_number = unnumbered.position;
+
+-- +goose Down
+ALTER TABLE show_seats
+    DROP INDEX idx_show_row,
+    DROP COLUMN category,
+    DROP COLUMN seat_number,
+    DROP COLUMN row_label;
+
+ALTER TABLE shows
+    DROP FOREIGN KEY fk_shows_screen,
+    DROP INDEX idx_screen_id,
+    DROP COLUMN screen_id;
+
+DROP TABLE IF EXISTS screens;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE theatres
+    ADD COLUMN prevent_single_seat_gaps BOOLEAN NOT NULL DEFAULT TRUE AFTER time_zone;
+
+-- +goose Down
+ALTER TABLE theatres DROP COLUMN prevent_single_seat_gaps;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE show_seats
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 0 AFTER hold_id;
+
+-- +goose Down
+ALTER TABLE show_seats DROP COLUMN version;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE shows
+    ADD COLUMN waiting_room_enabled BOOLEAN NOT NULL DEFAULT FALSE AFTER sales_close_at;
+
+-- +goose Down
+ALTER TABLE shows DROP COLUMN waiting_room_enabled;
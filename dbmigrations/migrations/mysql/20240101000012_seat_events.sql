@@ -0,0 +1,25 @@
+-- +goose Up
+-- Append-only: rows are only ever inserted, in the same transaction as the show_seats update they describe
+CREATE TABLE IF NOT EXISTS seat_events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    show_seat_id INT UNSIGNED NOT NULL,
+    show_id INT UNSIGNED NOT NULL,
+    transition VARCHAR(20) NOT NULL,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    prev_user_id INT UNSIGNED NULL,
+    prev_hold_id INT UNSIGNED NULL,
+    user_id INT UNSIGNED NULL,
+    hold_id INT UNSIGNED NULL,
+    booking_id INT UNSIGNED NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_user_id INT UNSIGNED NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP(3),
+    INDEX idx_show_seat_id (show_seat_id, id),
+    INDEX idx_show_id (show_id),
+    FOREIGN KEY (show_seat_id) REFERENCES show_seats(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +goose Down
+DROP TABLE IF EXISTS seat_events;
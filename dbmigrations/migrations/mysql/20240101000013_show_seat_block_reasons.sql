@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE show_seats
+    ADD COLUMN block_reason VARCHAR(255) NULL AFTER status;
+
+-- +goose Down
+UPDATE show_seats SET status = 'AVAILABLE' WHERE status = 'BLOCKED';
+ALTER TABLE show_seats DROP COLUMN block_reason;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    show_id INT UNSIGNED NOT NULL,
+    user_id INT UNSIGNED NOT NULL,
+    seat_count INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'WAITING',
+    hold_id INT UNSIGNED NULL,
+    offered_at TIMESTAMP NULL,
+    offer_expires_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_show_status (show_id, status, id),
+    INDEX idx_user_show (user_id, show_id),
+    INDEX idx_hold_id (hold_id),
+    FOREIGN KEY (show_id) REFERENCES shows(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- +goose Down
+DROP TABLE IF EXISTS waitlist_entries;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Covers the per-show seat version aggregate, so conditional seat map requests never read full rows
+CREATE INDEX idx_show_seats_version ON show_seats (show_id, version, status, locked_at);
+
+-- +goose Down
+DROP INDEX idx_show_seats_version ON show_seats;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE bookings
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'CONFIRMED' AFTER seat_id,
+    ADD COLUMN refund_amount DECIMAL(10,2) NULL AFTER amount,
+    ADD COLUMN cancelled_at TIMESTAMP NULL AFTER refund_amount;
+
+ALTER TABLE theatres
+    ADD COLUMN full_refund_hours INT NOT NULL DEFAULT 24 AFTER prevent_single_seat_gaps,
+    ADD COLUMN partial_refund_hours INT NOT NULL DEFAULT 2 AFTER full_refund_hours,
+    ADD COLUMN partial_refund_percent INT NOT NULL DEFAULT 50 AFTER partial_refund_hours;
+
+-- +goose Down
+ALTER TABLE theatres
+    DROP COLUMN partial_refund_percent,
+    DROP COLUMN partial_refund_hours,
+    DROP COLUMN full_refund_hours;
+
+ALTER TABLE bookings
+    DROP COLUMN cancelled_at,
+    DROP COLUMN refund_amount,
+    DROP COLUMN status;
@@ -0,0 +1,40 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS orders (
+    id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id INT UNSIGNED NOT NULL,
+    show_id INT UNSIGNED NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'CONFIRMED',
+    total_amount DECIMAL(10,2) NOT NULL DEFAULT 0.00,
+    idempotency_key VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_user_id (user_id),
+    INDEX idx_show_id (show_id),
+    INDEX idx_user_idempotency_key (user_id, idempotency_key),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (show_id) REFERENCES shows(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Every existing booking becomes a one-seat order with the same ID
+INSERT INTO orders (id, user_id, show_id, status, total_amount, idempotency_key, created_at, updated_at)
+SELECT id, user_id, show_id, status, amount, idempotency_key, created_at, updated_at FROM bookings;
+
+ALTER TABLE bookings ADD COLUMN order_id INT UNSIGNED NULL AFTER id;
+UPDATE bookings SET order_id = id;
+ALTER TABLE bookings
+    MODIFY COLUMN order_id INT UNSIGNED NOT NULL,
+    ADD INDEX idx_order_id (order_id),
+    ADD CONSTRAINT fk_bookings_order_id FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    DROP INDEX idx_idempotency_key,
+    DROP COLUMN idempotency_key;
+
+-- +goose Down
+ALTER TABLE bookings ADD COLUMN idempotency_key VARCHAR(255) AFTER amount;
+UPDATE bookings b JOIN orders o ON o.id = b.order_id SET b.idempotency_key = o.idempotency_key;
+ALTER TABLE bookings
+    ADD INDEX idx_idempotency_key (idempotency_key),
+    DROP FOREIGN KEY fk_bookings_order_id,
+    DROP INDEX idx_order_id,
+    DROP COLUMN order_id;
+
+DROP TABLE IF EXISTS orders;
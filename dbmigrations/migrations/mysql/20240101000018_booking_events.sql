@@ -0,0 +1,25 @@
+-- +goose Up
+-- Append-only: rows are only ever inserted, in the same transaction as the bookings change they describe
+CREATE TABLE IF NOT EXISTS booking_events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    booking_id INT UNSIGNED NOT NULL,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_user_id INT UNSIGNED NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP(3),
+    INDEX idx_booking_id (booking_id, id),
+    FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Reconstruct the history of existing bookings from their timestamps
+INSERT INTO booking_events (booking_id, from_status, to_status, actor_type, actor_user_id, reason, created_at)
+SELECT id, '', 'CONFIRMED', 'USER', user_id, 'booking created', created_at FROM bookings;
+
+INSERT INTO booking_events (booking_id, from_status, to_status, actor_type, actor_user_id, reason, created_at)
+SELECT id, 'CONFIRMED', 'CANCELLED', 'USER', user_id, 'booking cancelled', cancelled_at FROM bookings
+WHERE status = 'CANCELLED' AND cancelled_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS booking_events;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE bookings
+    ADD COLUMN base_price DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER amount,
+    ADD COLUMN format_surcharge DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER base_price;
+
+-- Existing bookings only recorded the total: split it at the show's current base price
+UPDATE bookings b
+JOIN shows s ON s.id = b.show_id
+SET b.base_price = LEAST(s.base_price, b.amount),
+    b.format_surcharge = b.amount - LEAST(s.base_price, b.amount);
+
+-- +goose Down
+ALTER TABLE bookings
+    DROP COLUMN format_surcharge,
+    DROP COLUMN base_price;
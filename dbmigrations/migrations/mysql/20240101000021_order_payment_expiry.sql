@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE orders ADD INDEX idx_status_created_at (status, created_at);
+
+-- +goose Down
+ALTER TABLE orders DROP INDEX idx_status_created_at;
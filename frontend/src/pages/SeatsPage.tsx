@@ -1,13 +1,13 @@
 import React, { useState, useEffect } from 'react';
 import { useParams, useNavigate } from 'react-router-dom';
 import { apiService } from '../services/api';
-import { ShowSeat } from '../types';
+import { SeatMap, SeatMapSeat } from '../types';
 import './SeatsPage.css';
 
 const SeatsPage: React.FC = () => {
   const { showId } = useParams<{ showId: string }>();
   const navigate = useNavigate();
-  const [seats, setSeats] = useState<ShowSeat[]>([]);
+  const [seatMap, setSeatMap] = useState<SeatMap | null>(null);
   const [selectedSeat, setSelectedSeat] = useState<number | null>(null);
   const [lockedSeat, setLockedSeat] = useState<number | null>(null);
   const [loading, setLoading] = useState(true);
@@ -32,9 +32,9 @@ const SeatsPage: React.FC = () => {
 
   const loadSeats = async (id: number) => {
     try {
-      const response = await apiService.getSeatsByShow(id);
+      const response = await apiService.getSeatMap(id);
       if (response.success && response.values) {
-        setSeats(response.values);
+        setSeatMap(response.values);
       } else {
         setError(response.message || 'Failed to load seats');
       }
@@ -45,20 +45,20 @@ const SeatsPage: React.FC = () => {
     }
   };
 
-  const handleSeatClick = async (seat: ShowSeat) => {
+  const handleSeatClick = async (seat: SeatMapSeat) => {
     if (seat.status === 'SOLD') {
       setError('This seat is already sold');
       return;
     }
 
-    if (seat.status === 'LOCKED' && seat.locked_at) {
-      const lockTime = new Date(seat.locked_at);
-      const now = new Date();
-      const lockDuration = 10 * 60 * 1000; // 10 minutes
-      if (now.getTime() - lockTime.getTime() < lockDuration) {
-        setError('This seat is currently locked by another user');
-        return;
-      }
+    if (seat.status === 'BLOCKED') {
+      setError('This seat is not available');
+      return;
+    }
+
+    if (seat.status === 'LOCKED' && seat.id !== lockedSeat) {
+      setError('This seat is currently locked by another user');
+      return;
     }
 
     try {
@@ -104,8 +104,9 @@ const SeatsPage: React.FC = () => {
     }
   };
 
-  const getSeatStatusClass = (seat: ShowSeat): string => {
+  const getSeatStatusClass = (seat: SeatMapSeat): string => {
     if (seat.status === 'SOLD') return 'seat-sold';
+    if (seat.status === 'BLOCKED') return 'seat-blocked';
     if (seat.status === 'LOCKED') {
       if (seat.id === lockedSeat) return 'seat-locked-by-me';
       return 'seat-locked';
@@ -114,39 +115,26 @@ const SeatsPage: React.FC = () => {
     return 'seat-available';
   };
 
-  const getSeatStatusLabel = (seat: ShowSeat): string => {
-    if (seat.status === 'SOLD') return 'Sold';
+  const getSeatStatusLabel = (seat: SeatMapSeat): string => {
+    let label = 'Available';
+    if (seat.status === 'SOLD') label = 'Sold';
+    if (seat.status === 'BLOCKED') label = seat.block_reason ? `Unavailable (${seat.block_reason})` : 'Unavailable';
     if (seat.status === 'LOCKED') {
-      if (seat.id === lockedSeat) return 'Your Lock';
-      return 'Locked';
+      label = seat.id === lockedSeat ? 'Your Lock' : 'Locked';
     }
-    return 'Available';
+    return `${seat.name} · ${seat.category} · ${seat.price.toFixed(2)} · ${label}`;
   };
 
-  // Organize seats into rows (assuming A1-A10, B1-B10, etc.)
-  const organizeSeats = () => {
-    const rows: { [key: string]: ShowSeat[] } = {};
-    seats.forEach((seat) => {
-      const row = seat.seat_name.charAt(0);
-      if (!rows[row]) {
-        rows[row] = [];
+  const findSeat = (id: number): SeatMapSeat | undefined => {
+    for (const row of seatMap?.rows ?? []) {
+      for (const cell of row.cells) {
+        if (cell.seat?.id === id) return cell.seat;
       }
-      rows[row].push(seat);
-    });
-
-    // Sort seats within each row
-    Object.keys(rows).forEach((row) => {
-      rows[row].sort((a, b) => {
-        const numA = parseInt(a.seat_name.substring(1));
-        const numB = parseInt(b.seat_name.substring(1));
-        return numA - numB;
-      });
-    });
-
-    return rows;
+    }
+    return undefined;
   };
 
-  const seatRows = organizeSeats();
+  const categoryLegend = (seatMap?.legend ?? []).filter((entry) => entry.kind === 'CATEGORY');
 
   return (
     <div className="seats-page">
@@ -161,31 +149,51 @@ const SeatsPage: React.FC = () => {
         {loading && <div className="loading">Loading seats...</div>}
         {error && <div className="error-message">{error}</div>}
 
-        {!loading && (
+        {!loading && seatMap && (
           <>
-            <div className="screen-indicator">SCREEN</div>
+            {seatMap.screen.position === 'FRONT' && (
+              <div className="screen-indicator">{seatMap.screen.label}</div>
+            )}
 
             <div className="seats-container">
-              {Object.keys(seatRows).sort().map((row) => (
-                <div key={row} className="seat-row">
-                  <div className="row-label">{row}</div>
+              {seatMap.rows.map((row) => (
+                <div key={row.label} className="seat-row">
+                  <div className="row-label">{row.label}</div>
                   <div className="seats-in-row">
-                    {seatRows[row].map((seat) => (
-                      <button
-                        key={seat.id}
-                        className={`seat ${getSeatStatusClass(seat)}`}
-                        onClick={() => handleSeatClick(seat)}
-                        disabled={seat.status === 'SOLD' || (seat.status === 'LOCKED' && seat.id !== lockedSeat)}
-                        title={getSeatStatusLabel(seat)}
-                      >
-                        {seat.seat_name.substring(1)}
-                      </button>
-                    ))}
+                    {row.cells.map((cell) =>
+                      cell.seat ? (
+                        <button
+                          key={cell.seat.id}
+                          className={`seat seat-category-${cell.seat.category.toLowerCase()} ${getSeatStatusClass(cell.seat)}`}
+                          onClick={() => cell.seat && handleSeatClick(cell.seat)}
+                          disabled={cell.seat.status === 'SOLD' || cell.seat.status === 'BLOCKED' || (cell.seat.status === 'LOCKED' && cell.seat.id !== lockedSeat)}
+                          title={getSeatStatusLabel(cell.seat)}
+                        >
+                          {cell.seat.number}
+                        </button>
+                      ) : (
+                        <div key={`${row.label}-${cell.column}`} className={`seat-spacer seat-${cell.type.toLowerCase()}`} />
+                      )
+                    )}
                   </div>
                 </div>
               ))}
             </div>
 
+            {categoryLegend.length > 0 && (
+              <div className="legend">
+                {categoryLegend.map((entry) => (
+                  <div key={entry.value} className="legend-item">
+                    <div className={`seat-legend seat-category-${entry.value.toLowerCase()}`}></div>
+                    <span>
+                      {entry.label}
+                      {entry.price !== undefined && ` · ${entry.price.toFixed(2)}`}
+                    </span>
+                  </div>
+                ))}
+              </div>
+            )}
+
             <div className="legend">
               <div className="legend-item">
                 <div className="seat-legend seat-available"></div>
@@ -207,12 +215,16 @@ const SeatsPage: React.FC = () => {
                 <div className="seat-legend seat-sold"></div>
                 <span>Sold</span>
               </div>
+              <div className="legend-item">
+                <div className="seat-legend seat-blocked"></div>
+                <span>Unavailable</span>
+              </div>
             </div>
 
             {selectedSeat && lockedSeat && (
               <div className="booking-section">
                 <div className="selected-seat-info">
-                  <h3>Selected Seat: {seats.find((s) => s.id === selectedSeat)?.seat_name}</h3>
+                  <h3>Selected Seat: {findSeat(selectedSeat)?.name}</h3>
                   {lockExpiresAt && (
                     <p>Lock expires at: {lockExpiresAt.toLocaleTimeString()}</p>
                   )}
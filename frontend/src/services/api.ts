@@ -6,6 +6,7 @@ import {
   Movie,
   Show,
   ShowSeat,
+  SeatMap,
   LockSeatResponse,
   CreateBookingRequest,
   BookingResponse,
@@ -76,9 +77,18 @@ class ApiService {
   }
 
   // Seat endpoints
+  async getSeatMap(showId: number): Promise<ApiResponse<SeatMap>> {
+    const response = await this.client.get<ApiResponse<SeatMap>>(
+      `/api/v1/shows/${showId}/seats`
+    );
+    return response.data;
+  }
+
+  // Flat seat list, kept for older screens
   async getSeatsByShow(showId: number): Promise<ApiResponse<ShowSeat[]>> {
     const response = await this.client.get<ApiResponse<ShowSeat[]>>(
-      `/api/v1/shows/${showId}/seats`
+      `/api/v1/shows/${showId}/seats`,
+      { params: { flat: true } }
     );
     return response.data;
   }
@@ -3,6 +3,7 @@ export interface ApiResponse<T> {
   success: boolean;
   statusCode: number;
   message?: string;
+  errorCode?: string;
   values?: T;
   error?: FieldError[];
 }
@@ -17,6 +18,7 @@ export interface User {
   id: number;
   name: string;
   email: string;
+  role?: string;
 }
 
 export interface LoginRequest {
@@ -45,6 +47,12 @@ export interface Theatre {
   id: number;
   name: string;
   location: string;
+  time_zone: string;
+}
+
+export interface FormatSurcharge {
+  format: string;
+  surcharge: number;
 }
 
 export interface Show {
@@ -52,6 +60,15 @@ export interface Show {
   movie_id: number;
   theatre_id: number;
   start_time: string;
+  local_start_time?: string;
+  utc_offset?: string;
+  format: string;
+  audio_language: string;
+  subtitle_language?: string;
+  base_price: number;
+  sales_open_at?: string;
+  sales_close_at?: string;
+  format_surcharge?: FormatSurcharge;
   movie?: Movie;
   theatre?: Theatre;
 }
@@ -61,24 +78,83 @@ export interface ShowSeat {
   id: number;
   show_id: number;
   seat_name: string;
-  status: 'AVAILABLE' | 'LOCKED' | 'SOLD';
+  row_label: string;
+  seat_number: number;
+  category: string;
+  status: SeatStatus;
   locked_at?: string;
   user_id?: number;
+  hold_id?: number;
+}
+
+export type SeatStatus = 'AVAILABLE' | 'LOCKED' | 'SOLD' | 'BLOCKED';
+
+// Structured seat map (GET /shows/{id}/seats)
+export interface SeatMapSeat {
+  id: number;
+  name: string;
+  number: number;
+  category: string;
+  price: number;
+  status: SeatStatus;
+  expires_at?: string;
+  block_reason?: string;
+}
+
+export interface SeatMapCell {
+  type: 'SEAT' | 'GAP' | 'AISLE';
+  column: number;
+  seat?: SeatMapSeat;
+}
+
+export interface SeatMapRow {
+  label: string;
+  index: number;
+  cells: SeatMapCell[];
+}
+
+export interface SeatMapLegendEntry {
+  kind: 'CATEGORY' | 'STATUS';
+  value: string;
+  label: string;
+  price?: number;
+}
+
+export interface SeatMap {
+  show_id: number;
+  screen: { position: string; label: string };
+  columns: number;
+  rows: SeatMapRow[];
+  legend: SeatMapLegendEntry[];
 }
 
 export interface LockSeatResponse {
   message: string;
+  hold_id: number;
+  seat_ids?: number[];
   expires_at: string;
 }
 
 // Booking Types
 export interface CreateBookingRequest {
   show_id: number;
+  seat_id?: number;
+  seat_ids?: number[];
+  hold_id?: number;
+}
+
+export interface BookingLineItem {
+  booking_id: number;
   seat_id: number;
+  seat_name: string;
+  amount: number;
 }
 
 export interface BookingResponse {
+  order_id: number;
   booking_id: number;
   status: string;
+  amount: number;
+  items: BookingLineItem[];
   message: string;
 }
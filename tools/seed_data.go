@@ -17,7 +17,7 @@ func main() {
 	}
 
 	// Connect to database
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%%27%%2B00%%3A00%%27",
 		config.GetDatabaseUser(),
 		config.GetDatabasePassword(),
 		config.GetDatabaseHost(),
@@ -143,6 +143,26 @@ func main() {
 		theatreIDs = append(theatreIDs, theatreID)
 	}
 
+	// Create one screen per theatre; seeded shows play on it
+	screenIDs := map[uint]uint{}
+	for _, theatreID := range theatreIDs {
+		var screenID uint
+		db.Raw("SELECT id FROM screens WHERE theatre_id = ? AND name = ?", theatreID, "Screen 1").Scan(&screenID)
+		if screenID == 0 {
+			result := db.Exec(`
+				INSERT INTO screens (theatre_id, name, layout, created_at, updated_at)
+				VALUES (?, 'Screen 1', 'STANDARD', NOW(), NOW())
+			`, theatreID)
+			if result.Error != nil {
+				log.Printf("Error inserting screen for theatre %d: %v", theatreID, result.Error)
+				continue
+			}
+			db.Raw("SELECT id FROM screens WHERE theatre_id = ? AND name = ?", theatreID, "Screen 1").Scan(&screenID)
+			fmt.Printf("✓ Created screen: Screen 1 at Theatre ID %d\n", theatreID)
+		}
+		screenIDs[theatreID] = screenID
+	}
+
 	// Create shows (one show per movie per theatre, at different times)
 	now := time.Now()
 	showTimes := []time.Time{
@@ -173,9 +193,9 @@ func main() {
 				if count == 0 {
 					// Create new show
 					result := db.Exec(`
-						INSERT INTO shows (movie_id, theatre_id, start_time, created_at, updated_at)
-						VALUES (?, ?, ?, NOW(), NOW())
-					`, movieID, theatreID, showTime)
+						INSERT INTO shows (movie_id, theatre_id, screen_id, start_time, created_at, updated_at)
+						VALUES (?, ?, ?, ?, NOW(), NOW())
+					`, movieID, theatreID, screenIDs[theatreID], showTime)
 					if result.Error != nil {
 						log.Printf("Error inserting show: %v", result.Error)
 						continue
@@ -187,8 +207,9 @@ func main() {
 					db.Raw("SELECT id FROM shows WHERE movie_id = ? AND theatre_id = ? AND ABS(TIMESTAMPDIFF(SECOND, start_time, ?)) < 60",
 						movieID, theatreID, showTime).Scan(&showID)
 				} else {
-					// Use existing show ID
+					// Use existing show ID, placing shows seeded before screens existed on the theatre's screen
 					showID = existingShowID
+					db.Exec("UPDATE shows SET screen_id = ? WHERE id = ? AND screen_id IS NULL", screenIDs[theatreID], showID)
 					fmt.Printf("- Show already exists: Movie ID %d at Theatre ID %d (ID: %d)\n", movieID, theatreID, showID)
 				}
 
@@ -199,9 +220,10 @@ func main() {
 		}
 	}
 
-	// Create seats for each show (50 seats: A1-A10, B1-B10, C1-C10, D1-D10, E1-E10)
+	// Create seats for each show (50 seats: A1-A10, B1-B10, C1-C10, D1-D10, E1-E10), the back row premium
 	rows := []string{"A", "B", "C", "D", "E"}
 	seatsPerRow := 10
+	premiumRow := "E"
 
 	seatsCreated := 0
 	for _, showID := range showIDs {
@@ -216,10 +238,14 @@ func main() {
 			for _, row := range rows {
 				for i := 1; i <= seatsPerRow; i++ {
 					seatName := fmt.Sprintf("%s%d", row, i)
+					category := "STANDARD"
+					if row == premiumRow {
+						category = "PREMIUM"
+					}
 					result := db.Exec(`
-						INSERT INTO show_seats (show_id, seat_name, status, created_at, updated_at)
-						VALUES (?, ?, 'AVAILABLE', NOW(), NOW())
-					`, showID, seatName)
+						INSERT INTO show_seats (show_id, seat_name, row_label, seat_number, category, status, created_at, updated_at)
+						VALUES (?, ?, ?, ?, ?, 'AVAILABLE', NOW(), NOW())
+					`, showID, seatName, row, i, category)
 					if result.Error != nil {
 						log.Printf("Error inserting seat %s for show %d: %v", seatName, showID, result.Error)
 						continue
@@ -229,6 +255,13 @@ func main() {
 			}
 			fmt.Printf("✓ Created 50 seats for show ID %d\n", showID)
 		} else {
+			// Seats seeded before positions were written get them from their names, as the screens migration does
+			db.Exec(`
+				UPDATE show_seats
+				SET row_label = REGEXP_REPLACE(seat_name, '[0-9]+$', ''),
+				    seat_number = CAST(REGEXP_SUBSTR(seat_name, '[0-9]+$') AS UNSIGNED)
+				WHERE show_id = ? AND row_label = ''
+			`, showID)
 			fmt.Printf("- Seats already exist for show ID %d (%d seats)\n", showID, seatCount)
 		}
 	}
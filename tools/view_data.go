@@ -16,7 +16,7 @@ func main() {
 	}
 
 	// Connect to database
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%%27%%2B00%%3A00%%27",
 		config.GetDatabaseUser(),
 		config.GetDatabasePassword(),
 		config.GetDatabaseHost(),
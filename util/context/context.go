@@ -2,12 +2,14 @@ package context
 
 import (
 	"context"
+	"movie-booking/constants"
 	"movie-booking/core/model"
 )
 
 // Context keys
 const (
 	UserID     = "userID"
+	UserRole   = "userRole"
 	Datastore  = "datastore"
 	GormAccessor = "gormaccessor"
 )
@@ -23,6 +25,29 @@ func SetUserID(ctx context.Context, userID uint) context.Context {
 	return context.WithValue(ctx, UserID, userID)
 }
 
+// GetUserRole extracts user role from context
+func GetUserRole(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(UserRole).(string)
+	return role, ok
+}
+
+// SetUserRole sets user role in context
+func SetUserRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, UserRole, role)
+}
+
+// IsStaff reports whether the authenticated user has a staff role
+func IsStaff(ctx context.Context) bool {
+	role, _ := GetUserRole(ctx)
+	return constants.IsStaffRole(role)
+}
+
+// IsAdmin reports whether the authenticated user has the admin role
+func IsAdmin(ctx context.Context) bool {
+	role, _ := GetUserRole(ctx)
+	return constants.IsAdminRole(role)
+}
+
 // GetDataStore extracts datastore from context
 func GetDataStore(ctx context.Context) (model.DataStore, bool) {
 	ds, ok := ctx.Value(Datastore).(model.DataStore)
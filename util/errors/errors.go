@@ -1,12 +1,14 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 )
 
 // HTTPError represents an HTTP error with status code
 type HTTPError struct {
 	StatusCode int
+	Code       string // Machine-readable error code, optional
 	Message    string
 	Err        error
 }
@@ -42,8 +44,22 @@ func NewHTTPError(statusCode int, message string) *HTTPError {
 	}
 }
 
-// IsHTTPError checks if an error is an HTTP error
+// NewHTTPErrorWithCode creates a new HTTP error carrying a machine-readable code
+func NewHTTPErrorWithCode(statusCode int, code string, message string) *HTTPError {
+	return &HTTPError{
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+	}
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// IsHTTPError checks if an error is (or wraps) an HTTP error
 func IsHTTPError(err error) (*HTTPError, bool) {
-	httpErr, ok := err.(*HTTPError)
+	var httpErr *HTTPError
+	ok := stderrors.As(err, &httpErr)
 	return httpErr, ok
 }